@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/backport"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	configPath  = flag.String("config", "pkg/config/config.yaml", "Path to config.yaml, the repos of a Konveyor release to report on")
+	days        = flag.Int("days", 90, "Report on the PRs merged in the last days")
+	reportFlags = action.NewReportFlags(action.FormatMarkdown, "Format of the report: markdown or json")
+)
+
+func main() {
+	flag.Parse()
+	if err := reportFlags.SetupLogging(); err != nil {
+		logrus.WithError(err).Fatal("Invalid log level")
+	}
+
+	if *days <= 0 {
+		logrus.Fatalf("--days must be >= 1, got %d", *days)
+	}
+
+	c, err := config.LoadConfig(*configPath)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load config")
+	}
+
+	since := time.Now().AddDate(0, 0, -*days)
+	report := backport.NewReporter(action.GetClient()).Report(context.Background(), c.ReleaseRepos(), since)
+
+	body, err := backport.Render(report, *reportFlags.Format)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to render the backport report")
+	}
+	if err := reportFlags.Write([]byte(body)); err != nil {
+		logrus.WithError(err).Fatal("Failed to write the backport report")
+	}
+
+	pending := len(report.Pending())
+	if err := action.SetOutput("pending", fmt.Sprint(pending)); err != nil {
+		logrus.WithError(err).Warn("Unable to set pending output")
+	}
+	if pending > 0 {
+		action.WarningCommand(fmt.Sprintf("%d backports are pending", pending))
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/backport"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	repository = flag.String("repo", "", "Repository of the PR, as org/repo")
+	number     = flag.Int("pr", 0, "Number of the merged PR to cherry-pick")
+	dir        = flag.String("dir", ".", "Clone of the repository to cherry-pick in")
+	postResult = flag.Bool("post-result", true, "Post the result of each cherry-pick as a comment on the PR")
+	author     = flag.String("comment-author", "", "Login the token comments as, defaults to the authenticated user or "+action.DefaultCommentAuthor)
+	logFlags   = action.NewLogFlags()
+)
+
+func main() {
+	flag.Parse()
+
+	if err := logFlags.SetupLogging(); err != nil {
+		logrus.WithError(err).Fatal("Invalid log level")
+	}
+
+	org, repo, ok := strings.Cut(*repository, "/")
+	if !ok || org == "" || repo == "" {
+		logrus.Fatalf("--repo must be org/repo, got %q", *repository)
+	}
+
+	ctx := context.Background()
+	client := action.GetClient()
+
+	pull, _, err := client.PullRequests.Get(ctx, org, repo, *number)
+	if err != nil {
+		logrus.WithError(err).Fatalf("Failed to get PR #%d", *number)
+	}
+	if !pull.GetMerged() {
+		action.NoticeCommand(fmt.Sprintf("PR #%d is not merged, nothing to cherry-pick", *number))
+		return
+	}
+
+	var labels []string
+	for _, label := range pull.Labels {
+		labels = append(labels, label.GetName())
+	}
+	branches := backport.TargetBranches(labels)
+	if len(branches) == 0 {
+		action.NoticeCommand(fmt.Sprintf("PR #%d has no cherry-pick label", *number))
+		return
+	}
+
+	commits, err := backport.MergedCommits(ctx, client, org, repo, pull)
+	if err != nil {
+		logrus.WithError(err).Fatalf("Failed to find the commits merged by PR #%d", *number)
+	}
+	if commits > 1 {
+		logrus.Infof("PR #%d was rebase merged, cherry-picking its %d commits", *number, commits)
+	}
+
+	p := &picker{client: client, git: backport.NewGit(*dir), org: org, repo: repo, pull: pull, commits: commits, author: *author}
+	if *postResult && p.author == "" {
+		p.author = action.CommentAuthor(ctx, client)
+	}
+	failed := false
+	for _, branch := range branches {
+		if err := p.cherryPick(ctx, branch); err != nil {
+			action.ErrorCommand(fmt.Sprintf("Failed to cherry-pick #%d to %s", *number, branch))
+			logrus.WithError(err).Errorf("Failed to cherry-pick #%d to %s", *number, branch)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+type picker struct {
+	client *github.Client
+	git    *backport.Git
+	org    string
+	repo   string
+	pull   *github.PullRequest
+	// commits is the number of commits the PR merged, ending at its merge
+	// commit SHA
+	commits int
+	// author is the login the client comments as
+	author string
+}
+
+// cherryPick cherry-picks the PR to the branch and opens the backport PR,
+// reporting conflicts on the PR
+func (p *picker) cherryPick(ctx context.Context, branch string) error {
+	number := p.pull.GetNumber()
+	name := backport.BranchName(number, branch)
+	marker := action.CommentMarker("cherry-pick " + branch)
+
+	err := p.git.CherryPickCommits(p.pull.GetMergeCommitSHA(), p.commits, branch, name)
+	if err == nil {
+		err = p.git.Push(name)
+	}
+	var url string
+	if err == nil {
+		url, err = p.openPR(ctx, branch, name)
+	}
+	if err != nil {
+		var conflicts []string
+		var conflict *backport.ConflictError
+		if errors.As(err, &conflict) {
+			conflicts = conflict.Files
+		}
+		if _, _, labelErr := p.client.Issues.AddLabelsToIssue(ctx, p.org, p.repo, number, []string{backport.FailedLabel(branch)}); labelErr != nil {
+			logrus.WithError(labelErr).Warnf("Failed to label #%d", number)
+		}
+		p.comment(ctx, marker, backport.ConflictComment(number, branch, backport.Revision(p.pull.GetMergeCommitSHA(), p.commits), conflicts))
+		return err
+	}
+
+	// A previous attempt may have failed
+	if resp, err := p.client.Issues.RemoveLabelForIssue(ctx, p.org, p.repo, number, backport.FailedLabel(branch)); err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		logrus.WithError(err).Warnf("Failed to unlabel #%d", number)
+	}
+	p.comment(ctx, marker, backport.SuccessComment(branch, url))
+	logrus.Infof("Cherry-picked #%d to %s in %s", number, branch, url)
+	return nil
+}
+
+// openPR opens the backport PR, or returns the one opened by a previous run
+func (p *picker) openPR(ctx context.Context, branch, name string) (string, error) {
+	existing, _, err := p.client.PullRequests.List(ctx, p.org, p.repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  p.org + ":" + name,
+		Base:  branch,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list PRs of %s: %w", name, err)
+	}
+	if len(existing) > 0 {
+		return existing[0].GetHTMLURL(), nil
+	}
+
+	created, _, err := p.client.PullRequests.Create(ctx, p.org, p.repo, &github.NewPullRequest{
+		Title: github.String(backport.Title(p.pull.GetTitle(), branch)),
+		Head:  github.String(name),
+		Base:  github.String(branch),
+		Body:  github.String(backport.Body(p.pull.GetNumber(), p.pull.GetBody())),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to open the backport PR: %w", err)
+	}
+	return created.GetHTMLURL(), nil
+}
+
+func (p *picker) comment(ctx context.Context, marker, body string) {
+	if !*postResult {
+		return
+	}
+	if err := action.EnsureComment(ctx, p.client, p.org, p.repo, p.pull.GetNumber(), p.author, marker, body); err != nil {
+		logrus.WithError(err).Warnf("Failed to comment on #%d", p.pull.GetNumber())
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/notes"
+	"github.com/konveyor/release-tools/pkg/pr"
+	"github.com/konveyor/release-tools/pkg/release"
+	"github.com/konveyor/release-tools/pkg/semver"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	repository  = flag.String("repo", "", "Repository to release, as org/repo")
+	version     = flag.String("version", "", "Semantic version of the release, e.g. v1.2.3 or v1.2.3-alpha.2")
+	prevVersion = flag.String("prev-version", "", "Previous release the notes start from, defaults to the previous semantic version tag")
+	ref         = flag.String("ref", "main", "Branch or SHA to release")
+	draft       = flag.Bool("draft", false, "Create the release as a draft, a published release is never turned back into a draft")
+	prerelease  = flag.Bool("prerelease", false, "Mark the release as a pre-release even if the version is not one")
+	prTypesPath = flag.String("pr-types", "", "Path to a PR type registry, defaults to the built-in PR types")
+	confirm     = flag.Bool("confirm", false, "Create or update the release via GitHub API")
+)
+
+func main() {
+	flag.Parse()
+
+	org, repo, ok := strings.Cut(*repository, "/")
+	if !ok || org == "" || repo == "" {
+		log.Fatalf("--repo must be org/repo, got %q", *repository)
+	}
+
+	v, err := release.ValidateVersion(*version, *ref)
+	if err != nil {
+		action.ErrorCommand("This is not a valid release version")
+		log.Fatal(err)
+	}
+
+	var registry *pr.Registry
+	if *prTypesPath != "" {
+		prTypes, err := config.LoadPRTypes(*prTypesPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		registry = pr.NewRegistry(prTypes)
+	}
+
+	ctx := context.Background()
+	client := action.GetClient()
+	generator := notes.NewGenerator(client, registry)
+
+	sha, _, err := client.Repositories.GetCommitSHA1(ctx, org, repo, *ref, "")
+	if err != nil {
+		action.ErrorCommand(fmt.Sprintf("Unable to resolve %s", *ref))
+		log.Fatal(err)
+	}
+
+	previous := *prevVersion
+	if previous == "" {
+		tags, err := generator.Tags(ctx, org, repo)
+		if err != nil {
+			log.Fatal(err)
+		}
+		previous, _ = semver.Previous(tags, v)
+	}
+
+	n, err := generator.Generate(ctx, org, repo, previous, sha)
+	if err != nil {
+		action.ErrorCommand("Failed to generate release notes")
+		log.Fatal(err)
+	}
+	// The notes link the tag, which exists once the release is created
+	n.To = v.String()
+
+	releaser := release.NewReleaser(client)
+	plan, err := releaser.Plan(ctx, org, repo, release.Spec{
+		Tag:        v.String(),
+		Target:     sha,
+		Name:       v.String(),
+		Body:       notes.RenderMarkdown(n),
+		Draft:      *draft,
+		Prerelease: *prerelease || v.IsPrerelease(),
+	})
+	if err != nil {
+		action.ErrorCommand("Unable to plan the release")
+		log.Fatal(err)
+	}
+
+	for key, value := range map[string]string{
+		"sha":           sha,
+		"prev_version":  previous,
+		"is_prerelease": fmt.Sprint(plan.Spec.Prerelease),
+		"is_dotzero":    fmt.Sprint(v.IsDotZero()),
+		"xy_version":    v.XY(),
+	} {
+		if err := action.SetOutput(key, value); err != nil {
+			log.Printf("warning: unable to set %s output: %v", key, err)
+		}
+	}
+
+	y, _ := yaml.Marshal(plan)
+	log.Print(string(y))
+	log.Print(plan.Spec.Body)
+
+	if plan.Action == release.ActionUnchanged {
+		action.NoticeCommand(fmt.Sprintf("Release %s is up to date", v))
+		os.Exit(0)
+	}
+
+	if !*confirm {
+		action.NoticeCommand("Running without confirm, no mutations will be made")
+		os.Exit(0)
+	}
+
+	r, err := releaser.Apply(ctx, plan)
+	if err != nil {
+		action.ErrorCommand(fmt.Sprintf("Unable to %s release %s", plan.Action, v))
+		log.Fatal(err)
+	}
+	if err := action.SetOutput("release_url", r.GetHTMLURL()); err != nil {
+		log.Printf("warning: unable to set release_url output: %v", err)
+	}
+	action.NoticeCommand(fmt.Sprintf("Release %s %sd: %s", v, plan.Action, r.GetHTMLURL()))
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/release"
+	"github.com/konveyor/release-tools/pkg/semver"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	configPath = flag.String("config", "", "Path to config.yaml")
+	branch     = flag.String("branch", "", "Release branch to cut, e.g. release-0.8")
+	from       = flag.String("from", "", "Branch, tag or SHA to cut the branch from in every repo, defaults to the head of the default branch")
+	shas       = flag.String("sha", "", "Comma separated org/repo=SHA overriding --from for some repos")
+	confirm    = flag.Bool("confirm", false, "Create the branches via GitHub API")
+)
+
+func main() {
+	flag.Parse()
+
+	if _, _, ok := semver.ReleaseBranch(*branch); !ok {
+		log.Fatalf("--branch must be a release-X.Y branch, got %q", *branch)
+	}
+
+	c, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repos := c.ReleaseRepos()
+	refs, err := parseSHAs(*shas, repos)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	releaser := release.NewReleaser(action.GetClient())
+
+	var plans []*release.BranchPlan
+	failed, diverged := false, false
+	for _, r := range repos {
+		ref := *from
+		if sha, ok := refs[r.Org+"/"+r.Repo]; ok {
+			ref = sha
+		}
+		plan, err := releaser.PlanBranch(ctx, r.Org, r.Repo, *branch, ref)
+		if err != nil {
+			action.ErrorCommand(fmt.Sprintf("Preflight of %s/%s failed", r.Org, r.Repo))
+			log.Printf("%s/%s: %v", r.Org, r.Repo, err)
+			failed = true
+			continue
+		}
+		if plan.Action == release.ActionDiverged {
+			action.WarningCommand(fmt.Sprintf("%s/%s: %s", r.Org, r.Repo, plan.Why))
+			diverged = true
+		}
+		plans = append(plans, plan)
+	}
+
+	y, _ := yaml.Marshal(plans)
+	log.Print(string(y))
+
+	// Nothing is created unless every repo can be branched
+	if failed {
+		log.Fatal("Preflight failed, no branch will be created")
+	}
+
+	if !*confirm {
+		action.NoticeCommand("Running without confirm, no mutations will be made")
+		os.Exit(0)
+	}
+
+	created := 0
+	for _, plan := range plans {
+		if plan.Action != release.ActionCreate {
+			continue
+		}
+		if err := releaser.CutBranch(ctx, plan); err != nil {
+			action.ErrorCommand(fmt.Sprintf("Unable to create %s in %s/%s", plan.Branch, plan.Org, plan.Repo))
+			log.Printf("%s/%s: %v", plan.Org, plan.Repo, err)
+			failed = true
+			continue
+		}
+		created++
+		log.Printf("Created %s in %s/%s at %s", plan.Branch, plan.Org, plan.Repo, plan.SHA)
+	}
+	action.NoticeCommand(fmt.Sprintf("Created %s in %d repos", *branch, created))
+	if diverged {
+		action.ErrorCommand(fmt.Sprintf("%s has diverged in some repos, it was left unchanged there", *branch))
+	}
+	if failed || diverged {
+		os.Exit(1)
+	}
+}
+
+// parseSHAs parses the org/repo=SHA overrides, which must be configured repos
+func parseSHAs(value string, repos []config.Repo) (map[string]string, error) {
+	refs := make(map[string]string)
+	if value == "" {
+		return refs, nil
+	}
+	known := make(map[string]bool)
+	for _, r := range repos {
+		known[r.Org+"/"+r.Repo] = true
+	}
+	for _, pair := range strings.Split(value, ",") {
+		repo, sha, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || sha == "" {
+			return nil, fmt.Errorf("--sha must be org/repo=SHA pairs, got %q", pair)
+		}
+		if !known[repo] {
+			return nil, fmt.Errorf("--sha: %s is not a configured repo of the release", repo)
+		}
+		refs[repo] = sha
+	}
+	return refs, nil
+}
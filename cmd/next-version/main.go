@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/notes"
+	"github.com/konveyor/release-tools/pkg/pr"
+	"github.com/konveyor/release-tools/pkg/semver"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	repository  = flag.String("repo", "", "Repository to compute the next version of, as org/repo")
+	branch      = flag.String("branch", "main", "Branch the release is made from, main or release-X.Y")
+	prTypesPath = flag.String("pr-types", "", "Path to a PR type registry, defaults to the built-in PR types")
+	logFlags    = action.NewLogFlags()
+)
+
+func main() {
+	flag.Parse()
+
+	if err := logFlags.SetupLogging(); err != nil {
+		logrus.WithError(err).Fatal("Invalid log level")
+	}
+
+	org, repo, ok := strings.Cut(*repository, "/")
+	if !ok || org == "" || repo == "" {
+		logrus.Fatalf("--repo must be org/repo, got %q", *repository)
+	}
+
+	var registry *pr.Registry
+	if *prTypesPath != "" {
+		prTypes, err := config.LoadPRTypes(*prTypesPath)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to load PR types")
+		}
+		registry = pr.NewRegistry(prTypes)
+	}
+
+	ctx := context.Background()
+	client := action.GetClient()
+	generator := notes.NewGenerator(client, registry)
+
+	tags, err := generator.Tags(ctx, org, repo)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to list tags")
+	}
+	releaseBranches, err := listReleaseBranches(ctx, client, org, repo)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to list release branches")
+	}
+
+	// The impact of the PRs merged since the last tag of the branch, or since
+	// the branch was cut from the default branch when it has no tag yet
+	last, _ := semver.LastTag(tags, *branch)
+	from := last
+	if from == "" {
+		from, err = branchPoint(ctx, client, org, repo, *branch)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to find where the branch was cut")
+		}
+	}
+	var pulls []*github.PullRequest
+	if from != "" {
+		pulls, err = generator.MergedPRs(ctx, org, repo, from, *branch)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to list merged PRs")
+		}
+	} else {
+		logrus.Infof("%s has no previous tag, skipping the impact of its PRs", *branch)
+	}
+	var impacts []config.SemverImpact
+	for _, pull := range pulls {
+		title, err := registry.ParseTitle(pull.GetTitle(), false)
+		if err != nil {
+			logrus.Warnf("Ignoring #%d, its title %q has no PR type prefix", pull.GetNumber(), pull.GetTitle())
+			continue
+		}
+		if t, ok := registry.Lookup(title.Type); ok {
+			impacts = append(impacts, t.Impact)
+		}
+	}
+	impact := semver.MaxImpact(impacts...)
+
+	next, err := semver.Next(tags, *branch, releaseBranches, impact)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to compute the next version")
+	}
+
+	if _, _, ok := semver.ReleaseBranch(*branch); ok && impact != config.ImpactNone && impact != config.ImpactPatch {
+		since := last
+		if since == "" {
+			since = "it was cut"
+		}
+		action.WarningCommand(fmt.Sprintf("%s has %s changes since %s, release branches should only receive fixes", *branch, impact, since))
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"branch":   *branch,
+		"previous": last,
+		"prs":      len(pulls),
+		"impact":   impact,
+		"version":  next,
+	}).Info("Computed the next version")
+	fmt.Println(next)
+
+	outputs := map[string]string{
+		"version":       next.String(),
+		"previous":      last,
+		"impact":        string(impact),
+		"is_prerelease": fmt.Sprint(next.IsPrerelease()),
+	}
+	for key, value := range outputs {
+		if err := action.SetOutput(key, value); err != nil {
+			logrus.WithError(err).Warnf("Unable to set %s output", key)
+		}
+	}
+}
+
+// listReleaseBranches returns the release-X.Y branches of the repo
+func listReleaseBranches(ctx context.Context, client *github.Client, org, repo string) ([]string, error) {
+	var branches []string
+	opts := &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, resp, err := client.Repositories.ListBranches(ctx, org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range page {
+			if _, _, ok := semver.ReleaseBranch(b.GetName()); ok {
+				branches = append(branches, b.GetName())
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return branches, nil
+}
+
+// branchPoint returns the merge-base of the branch with the default branch of
+// the repo, "" when the branch is the default branch
+func branchPoint(ctx context.Context, client *github.Client, org, repo, branch string) (string, error) {
+	r, _, err := client.Repositories.Get(ctx, org, repo)
+	if err != nil {
+		return "", err
+	}
+	base := r.GetDefaultBranch()
+	if base == strings.TrimPrefix(branch, "refs/heads/") {
+		return "", nil
+	}
+	comparison, _, err := client.Repositories.CompareCommits(ctx, org, repo, base, branch, &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return "", err
+	}
+	return comparison.GetMergeBaseCommit().GetSHA(), nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/prep"
+	"github.com/konveyor/release-tools/pkg/semver"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	dir        = flag.String("dir", ".", "Directory of the repository to prepare")
+	branch     = flag.String("branch", "", "Release branch to prepare, e.g. release-0.8 or refs/heads/release-0.8")
+	dockerfile = flag.String("dockerfile", "Dockerfile", "Path to the Dockerfile, relative to --dir")
+	images     = flag.String("images", "", "Comma separated images whose FROM references are retagged to the release branch")
+	goDeps     = flag.String("go-deps", "", "Comma separated Go modules required in go.mod to update to the head of their release branch")
+	confirm    = flag.Bool("confirm", false, "Write the changes to the files, only print the diff otherwise")
+	logFlags   = action.NewLogFlags()
+)
+
+func main() {
+	flag.Parse()
+
+	if err := logFlags.SetupLogging(); err != nil {
+		logrus.WithError(err).Fatal("Invalid log level")
+	}
+
+	name := strings.TrimPrefix(*branch, "refs/heads/")
+	if _, _, ok := semver.ReleaseBranch(name); !ok {
+		action.NoticeCommand(fmt.Sprintf("%s is not a release branch, nothing to prepare", *branch))
+		return
+	}
+
+	files := make(map[string][]byte)
+	var changes []prep.Change
+
+	if list := split(*images); len(list) > 0 {
+		path := filepath.Join(*dir, *dockerfile)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to read the Dockerfile")
+		}
+		retagged, c := prep.RetagDockerfile(*dockerfile, string(content), list, name)
+		if len(c) > 0 {
+			files[path] = []byte(retagged)
+			changes = append(changes, c...)
+		}
+	}
+
+	if list := split(*goDeps); len(list) > 0 {
+		resolver := prep.NewResolver(*dir)
+		versions := make(map[string]string)
+		for _, dep := range list {
+			version, err := resolver.BranchVersion(context.Background(), dep, name)
+			if err != nil {
+				logrus.WithError(err).Fatalf("Failed to resolve %s@%s", dep, name)
+			}
+			logrus.Infof("%s@%s is %s", dep, name, version)
+			versions[dep] = version
+		}
+
+		path := filepath.Join(*dir, "go.mod")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to read go.mod")
+		}
+		updated, c, err := prep.UpdateGoMod("go.mod", content, versions)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to update go.mod")
+		}
+		if len(c) > 0 {
+			files[path] = updated
+			changes = append(changes, c...)
+		}
+	}
+
+	if err := action.SetOutput("changed", fmt.Sprint(len(changes) > 0)); err != nil {
+		logrus.WithError(err).Warn("Unable to set changed output")
+	}
+	if len(changes) == 0 {
+		action.NoticeCommand(fmt.Sprintf("Already prepared for %s", name))
+		return
+	}
+	fmt.Print(prep.Diff(changes))
+
+	if !*confirm {
+		action.NoticeCommand("Running without confirm, no files will be changed")
+		return
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			logrus.WithError(err).Fatalf("Failed to write %s", path)
+		}
+	}
+}
+
+// split splits a comma separated list, ignoring empty items
+func split(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/manifest"
+	"github.com/konveyor/release-tools/pkg/registry"
+	"github.com/konveyor/release-tools/pkg/semver"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	configPath   = flag.String("config", "pkg/config/config.yaml", "Path to config.yaml, the repos and images of a Konveyor release")
+	version      = flag.String("version", "", "Semantic version of the Konveyor release to generate the manifest of, e.g. v0.8.0")
+	registryHost = flag.String("registry", "", "Registry host replacing the one of every image, e.g. localhost:5000")
+	plainHTTP    = flag.Bool("plain-http", false, "Talk to the registry over http, e.g. for a local registry")
+	diff         = flag.Bool("diff", false, "Diff the two manifests given as arguments, old then new, instead of generating one")
+	reportFlags  = action.NewReportFlags("", "Format of the manifest, yaml or json, or of the diff, markdown or json. Defaults to yaml and markdown")
+)
+
+func main() {
+	flag.Parse()
+	if err := reportFlags.SetupLogging(); err != nil {
+		logrus.WithError(err).Fatal("Invalid log level")
+	}
+
+	var out []byte
+	if *diff {
+		out = diffManifests()
+	} else {
+		out = generate()
+	}
+
+	if err := reportFlags.Write(out); err != nil {
+		logrus.WithError(err).Fatal("Failed to write")
+	}
+}
+
+func generate() []byte {
+	v, err := semver.Parse(*version)
+	if err != nil {
+		logrus.WithError(err).Fatal("Invalid version")
+	}
+	c, err := config.LoadConfig(*configPath)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load config")
+	}
+
+	generator := manifest.NewGenerator(action.GetClient(), registry.NewClient(registry.Options{
+		Host:      *registryHost,
+		PlainHTTP: *plainHTTP,
+		Username:  os.Getenv("REGISTRY_USERNAME"),
+		Password:  os.Getenv("REGISTRY_PASSWORD"),
+	}))
+	m, err := generator.Generate(context.Background(), c.Repos, v)
+	if err != nil {
+		action.ErrorCommand(fmt.Sprintf("Unable to generate the manifest of %s", v))
+		logrus.WithError(err).Fatal("Failed to generate the manifest")
+	}
+
+	format := *reportFlags.Format
+	if format == "" {
+		format = action.FormatYAML
+	}
+	out, err := manifest.Marshal(m, format)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to render the manifest")
+	}
+	return out
+}
+
+func diffManifests() []byte {
+	if flag.NArg() != 2 {
+		logrus.Fatal("--diff expects the old and the new manifests as arguments")
+	}
+	old, err := manifest.Load(flag.Arg(0))
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load the old manifest")
+	}
+	next, err := manifest.Load(flag.Arg(1))
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load the new manifest")
+	}
+	changes := manifest.Diff(old, next)
+
+	if err := action.SetOutput("changes", fmt.Sprint(len(changes))); err != nil {
+		logrus.WithError(err).Warn("Unable to set changes output")
+	}
+	switch *reportFlags.Format {
+	case "", action.FormatMarkdown, "md":
+		return []byte(manifest.RenderDiff(old, next, changes))
+	case action.FormatJSON:
+		out, err := action.MarshalJSON(changes)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to render the diff")
+		}
+		return out
+	}
+	logrus.Fatalf("Unknown diff format %q, expected %s or %s", *reportFlags.Format, action.FormatMarkdown, action.FormatJSON)
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/notes"
+	"github.com/konveyor/release-tools/pkg/pr"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	repository  = flag.String("repo", "", "Repository to generate release notes for, as org/repo")
+	from        = flag.String("from", "", "Previous release tag, defaults to the latest release")
+	to          = flag.String("to", "main", "Tag, branch or SHA of the release")
+	release     = flag.String("release", "", "Konveyor release, e.g. v0.8.0, to combine the release notes of every configured repo tagged with it")
+	configPath  = flag.String("config", "pkg/config/config.yaml", "Path to config.yaml, the repos of a Konveyor release")
+	prTypesPath = flag.String("pr-types", "", "Path to a PR type registry, defaults to the built-in PR types")
+	templateDir = flag.String("templates", "templates", "Directory holding the release-notes HTML templates")
+	reportFlags = action.NewReportFlags(notes.FormatMarkdown, "Format of the release notes: markdown, json, yaml or html")
+)
+
+func main() {
+	flag.Parse()
+
+	if err := reportFlags.SetupLogging(); err != nil {
+		logrus.WithError(err).Fatal("Invalid log level")
+	}
+
+	formatter, err := notes.NewFormatter(*reportFlags.Format)
+	if err != nil {
+		logrus.WithError(err).Fatal("Invalid format")
+	}
+	notes.TemplateDir = *templateDir
+
+	var registry *pr.Registry
+	if *prTypesPath != "" {
+		prTypes, err := config.LoadPRTypes(*prTypesPath)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to load PR types")
+		}
+		registry = pr.NewRegistry(prTypes)
+	}
+
+	ctx := context.Background()
+	client := action.GetClient()
+	generator := notes.NewGenerator(client, registry)
+
+	if *release != "" {
+		c, err := config.LoadConfig(*configPath)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to load config")
+		}
+		r, err := generator.GenerateRelease(ctx, c.ReleaseRepos(), *release)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to generate release notes")
+		}
+		write(formatter.FormatRelease(r))
+		return
+	}
+
+	org, repo, ok := strings.Cut(*repository, "/")
+	if !ok || org == "" || repo == "" {
+		logrus.Fatalf("--repo must be org/repo, got %q", *repository)
+	}
+
+	previous := *from
+	if previous == "" {
+		previous, err = latestRelease(ctx, client, org, repo)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to get the latest release")
+		}
+	}
+
+	n, err := generator.Generate(ctx, org, repo, previous, *to)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to generate release notes")
+	}
+	write(formatter.Format(n))
+
+	if err := action.SetOutput("prev_version", previous); err != nil {
+		logrus.WithError(err).Warn("Unable to set prev_version output")
+	}
+}
+
+// latestRelease returns the tag of the latest release, empty if the repo has
+// no release yet
+func latestRelease(ctx context.Context, client *github.Client, org, repo string) (string, error) {
+	release, resp, err := client.Repositories.GetLatestRelease(ctx, org, repo)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	return release.GetTagName(), nil
+}
+
+// write writes the rendered release notes to the output file or stdout
+func write(body string, err error) {
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to render release notes")
+	}
+	if err := reportFlags.Write([]byte(body)); err != nil {
+		logrus.WithError(err).Fatal("Failed to write release notes")
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/readiness"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	configPath    = flag.String("config", "pkg/config/config.yaml", "Path to config.yaml, the repos and milestones of Konveyor")
+	milestone     = flag.String("milestone", "", "Title of the milestone to check, one of the milestones of config.yaml")
+	blockerLabels = flag.String("blocker-labels", strings.Join(readiness.DefaultBlockerLabels, ","), "Comma separated labels of the issues and PRs blocking the release")
+	reportFlags   = action.NewReportFlags(action.FormatMarkdown, "Format of the report: markdown or json")
+)
+
+func main() {
+	flag.Parse()
+	if err := reportFlags.SetupLogging(); err != nil {
+		logrus.WithError(err).Fatal("Invalid log level")
+	}
+
+	c, err := config.LoadConfig(*configPath)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load config")
+	}
+	found := false
+	for _, m := range c.Milestones {
+		if m.Title == *milestone {
+			found = true
+			break
+		}
+	}
+	if !found {
+		action.ErrorCommand(fmt.Sprintf("Milestone %q is not in config.yaml", *milestone))
+		logrus.Fatalf("Unknown milestone %q", *milestone)
+	}
+
+	var labels []string
+	for _, label := range strings.Split(*blockerLabels, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+
+	checker := readiness.NewChecker(action.GetClient(), labels)
+	report := checker.Check(context.Background(), c.ReleaseRepos(), *milestone)
+
+	body, err := readiness.Render(report, *reportFlags.Format)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to render the readiness report")
+	}
+	if err := reportFlags.Write([]byte(body)); err != nil {
+		logrus.WithError(err).Fatal("Failed to write the readiness report")
+	}
+
+	if err := action.SetOutput("ready", fmt.Sprint(report.Ready())); err != nil {
+		logrus.WithError(err).Warn("Unable to set ready output")
+	}
+
+	if report.Blocked() {
+		action.ErrorCommand(fmt.Sprintf("Release %s is blocked", *milestone))
+		os.Exit(1)
+	}
+	if !report.Ready() {
+		action.WarningCommand(fmt.Sprintf("Release %s is not ready", *milestone))
+		return
+	}
+	action.NoticeCommand(fmt.Sprintf("Release %s is ready", *milestone))
+}
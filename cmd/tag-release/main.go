@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/notes"
+	"github.com/konveyor/release-tools/pkg/pr"
+	"github.com/konveyor/release-tools/pkg/readiness"
+	"github.com/konveyor/release-tools/pkg/release"
+	"github.com/konveyor/release-tools/pkg/semver"
+	"sigs.k8s.io/yaml"
+)
+
+// Plan of a repo
+type Plan struct {
+	Org  string `json:"org"`
+	Repo string `json:"repo"`
+	// CI is the CI state of the head of the release branch, it is not checked
+	// when the repo is already tagged
+	CI      string           `json:"ci,omitempty"`
+	Tag     *release.TagPlan `json:"tag"`
+	Release *release.Plan    `json:"release,omitempty"`
+}
+
+var (
+	configPath  = flag.String("config", "", "Path to config.yaml, the repos of a Konveyor release")
+	version     = flag.String("version", "", "Semantic version of the Konveyor release, e.g. v0.8.0 or v0.8.0-beta.1")
+	draft       = flag.Bool("draft", false, "Create the releases as drafts, a published release is never turned back into a draft")
+	allowNoCI   = flag.Bool("allow-no-ci", false, "Tag repos whose release branch has no status or check run")
+	prTypesPath = flag.String("pr-types", "", "Path to a PR type registry, defaults to the built-in PR types")
+	confirm     = flag.Bool("confirm", false, "Create the tags and releases via GitHub API")
+)
+
+func main() {
+	flag.Parse()
+
+	v, err := semver.Parse(*version)
+	if err != nil {
+		log.Fatal(err)
+	}
+	branch := "release-" + v.XY()
+	if _, err := release.ValidateVersion(*version, branch); err != nil {
+		log.Fatal(err)
+	}
+
+	c, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var registry *pr.Registry
+	if *prTypesPath != "" {
+		prTypes, err := config.LoadPRTypes(*prTypesPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		registry = pr.NewRegistry(prTypes)
+	}
+
+	ctx := context.Background()
+	client := action.GetClient()
+	releaser := release.NewReleaser(client)
+	checker := readiness.NewChecker(client, nil)
+	generator := notes.NewGenerator(client, registry)
+
+	// Every repo is checked before anything is created, so that a release is
+	// either tagged everywhere or nowhere
+	var plans []*Plan
+	failed := false
+	for _, r := range c.ReleaseRepos() {
+		plan, err := planRepo(ctx, releaser, checker, generator, r, v, branch)
+		if err != nil {
+			action.ErrorCommand(fmt.Sprintf("Preflight of %s/%s failed", r.Org, r.Repo))
+			log.Printf("%s/%s: %v", r.Org, r.Repo, err)
+			failed = true
+			continue
+		}
+		plans = append(plans, plan)
+	}
+
+	y, _ := yaml.Marshal(plans)
+	log.Print(string(y))
+
+	if failed {
+		log.Fatal("Preflight failed, no tag will be created")
+	}
+
+	if !*confirm {
+		action.NoticeCommand("Running without confirm, no mutations will be made")
+		os.Exit(0)
+	}
+
+	message := fmt.Sprintf("Konveyor %s", v)
+	for _, plan := range plans {
+		if err := releaser.CreateTag(ctx, plan.Tag, message); err != nil {
+			action.ErrorCommand(fmt.Sprintf("Unable to tag %s/%s", plan.Org, plan.Repo))
+			// Later repos are left for a re-run, which skips the tagged ones
+			log.Fatalf("%s/%s: %v", plan.Org, plan.Repo, err)
+		}
+		if plan.Release.Action == release.ActionUnchanged {
+			continue
+		}
+		r, err := releaser.Apply(ctx, plan.Release)
+		if err != nil {
+			action.ErrorCommand(fmt.Sprintf("Unable to %s the release of %s/%s", plan.Release.Action, plan.Org, plan.Repo))
+			log.Fatalf("%s/%s: %v", plan.Org, plan.Repo, err)
+		}
+		log.Printf("%s/%s: %s", plan.Org, plan.Repo, r.GetHTMLURL())
+	}
+	action.NoticeCommand(fmt.Sprintf("Konveyor %s is tagged in %d repos", v, len(plans)))
+}
+
+// planRepo checks the release branch of the repo is green and plans its tag
+// and release
+func planRepo(ctx context.Context, releaser *release.Releaser, checker *readiness.Checker, generator *notes.Generator, r config.Repo, v semver.Version, branch string) (*Plan, error) {
+	tag, err := releaser.PlanTag(ctx, r.Org, r.Repo, v.String(), branch)
+	if err != nil {
+		return nil, err
+	}
+	if tag.Action == release.ActionDiverged {
+		return nil, errors.New(tag.Why)
+	}
+	plan := &Plan{Org: r.Org, Repo: r.Repo, Tag: tag}
+
+	// A tagged repo passed the CI gate in the run that tagged it
+	if tag.Action == release.ActionCreate {
+		status, err := checker.BranchStatus(ctx, r.Org, r.Repo, branch)
+		if err != nil {
+			return nil, err
+		}
+		if status == nil || status.SHA != tag.SHA {
+			return nil, fmt.Errorf("%s moved while checking it, try again", branch)
+		}
+		plan.CI = status.State
+		switch {
+		case status.State == readiness.CINone && !*allowNoCI:
+			return nil, fmt.Errorf("%s has no CI, set -allow-no-ci to tag it anyway", branch)
+		case status.State == readiness.CIFailure || status.State == readiness.CIPending:
+			return nil, fmt.Errorf("CI of %s is %s %v", branch, status.State, status.Failed)
+		}
+	}
+
+	tags, err := generator.Tags(ctx, r.Org, r.Repo)
+	if err != nil {
+		return nil, err
+	}
+	previous, _ := semver.Previous(tags, v)
+	n, err := generator.Generate(ctx, r.Org, r.Repo, previous, tag.Target())
+	if err != nil {
+		return nil, err
+	}
+	n.To = v.String()
+
+	plan.Release, err = releaser.Plan(ctx, r.Org, r.Repo, release.Spec{
+		Tag:        v.String(),
+		Target:     tag.Target(),
+		Name:       v.String(),
+		Body:       notes.RenderMarkdown(n),
+		Draft:      *draft,
+		Prerelease: v.IsPrerelease(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
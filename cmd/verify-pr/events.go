@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/pr"
+)
+
+// target is what to verify for the event that triggered the action
+type target struct {
+	owner string
+	repo  string
+	// headSHA is the commit the check run is published on
+	headSHA string
+	pulls   []*github.PullRequest
+}
+
+// needsClient returns true if the PRs of the event must be fetched from the
+// API
+func needsClient(eventName string) bool {
+	return eventName == "merge_group" || eventName == "issue_comment"
+}
+
+// resolve returns the PRs to verify for the event, an empty target means
+// there is nothing to verify
+func (v *verifier) resolve(ctx context.Context, ghContext action.GitHubVariables) (target, error) {
+	eventFile, err := os.Open(ghContext.GithubEventPath)
+	if err != nil {
+		return target{}, fmt.Errorf("unable to load event file: %w", err)
+	}
+	defer eventFile.Close()
+	decoder := json.NewDecoder(eventFile)
+
+	switch ghContext.GithubEventName {
+	case "pull_request", "pull_request_target":
+		var event github.PullRequestEvent
+		if err := decoder.Decode(&event); err != nil {
+			return target{}, fmt.Errorf("unable to unmarshal PullRequest event: %w", err)
+		}
+		return target{
+			owner:   event.GetRepo().GetOwner().GetLogin(),
+			repo:    event.GetRepo().GetName(),
+			headSHA: event.GetPullRequest().GetHead().GetSHA(),
+			pulls:   []*github.PullRequest{event.GetPullRequest()},
+		}, nil
+
+	case "merge_group":
+		var event github.MergeGroupEvent
+		if err := decoder.Decode(&event); err != nil {
+			return target{}, fmt.Errorf("unable to unmarshal MergeGroup event: %w", err)
+		}
+		return v.resolveMergeGroup(ctx, event)
+
+	case "issue_comment":
+		var event github.IssueCommentEvent
+		if err := decoder.Decode(&event); err != nil {
+			return target{}, fmt.Errorf("unable to unmarshal IssueComment event: %w", err)
+		}
+		return v.resolveComment(ctx, event)
+
+	default:
+		return target{}, fmt.Errorf("unsupported event %q", ghContext.GithubEventName)
+	}
+}
+
+// resolveMergeGroup returns the PRs in the merge group. The branch of the
+// group names the last PR, the PRs queued before it are found in the merge
+// commits the group adds on top of its base.
+func (v *verifier) resolveMergeGroup(ctx context.Context, event github.MergeGroupEvent) (target, error) {
+	group := event.GetMergeGroup()
+	t := target{
+		owner:   event.GetRepo().GetOwner().GetLogin(),
+		repo:    event.GetRepo().GetName(),
+		headSHA: group.GetHeadSHA(),
+	}
+
+	var commits []pr.GroupCommit
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		comparison, resp, err := v.client.Repositories.CompareCommits(ctx, t.owner, t.repo, group.GetBaseSHA(), group.GetHeadSHA(), opts)
+		if err != nil {
+			return target{}, fmt.Errorf("unable to compare merge group commits: %w", err)
+		}
+		for _, c := range comparison.Commits {
+			commit := pr.GroupCommit{SHA: c.GetSHA(), Message: c.GetCommit().GetMessage()}
+			for _, parent := range c.Parents {
+				commit.Parents = append(commit.Parents, parent.GetSHA())
+			}
+			commits = append(commits, commit)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	numbers := pr.MergeGroupPRs(group.GetHeadRef(), group.GetHeadSHA(), group.GetBaseSHA(), commits)
+	if len(numbers) == 0 {
+		return target{}, fmt.Errorf("unable to find the PRs in merge group %s", group.GetHeadRef())
+	}
+
+	for _, number := range numbers {
+		pullRequest, _, err := v.client.PullRequests.Get(ctx, t.owner, t.repo, number)
+		if err != nil {
+			return target{}, fmt.Errorf("unable to get PR #%d: %w", number, err)
+		}
+		t.pulls = append(t.pulls, pullRequest)
+	}
+	return t, nil
+}
+
+// resolveComment returns the PR to verify when a comment on it asks for
+// /verify or /retitle, a /retitle also changes the title of the PR first
+func (v *verifier) resolveComment(ctx context.Context, event github.IssueCommentEvent) (target, error) {
+	if event.GetAction() != "created" || !event.GetIssue().IsPullRequest() {
+		return target{}, nil
+	}
+	commands := pr.CommandsFromComment(event.GetComment().GetBody())
+	if len(commands) == 0 {
+		return target{}, nil
+	}
+
+	t := target{
+		owner: event.GetRepo().GetOwner().GetLogin(),
+		repo:  event.GetRepo().GetName(),
+	}
+	pullRequest, _, err := v.client.PullRequests.Get(ctx, t.owner, t.repo, event.GetIssue().GetNumber())
+	if err != nil {
+		return target{}, fmt.Errorf("unable to get PR #%d: %w", event.GetIssue().GetNumber(), err)
+	}
+
+	for _, command := range commands {
+		if command.Name != pr.RetitleCommand {
+			continue
+		}
+		if command.Args == "" {
+			action.WarningCommand("/retitle needs the new title of the PR")
+			continue
+		}
+		allowed, err := v.canRetitle(ctx, t, pullRequest, event.GetComment().GetUser().GetLogin())
+		if err != nil {
+			return target{}, err
+		}
+		if !allowed {
+			action.WarningCommand(fmt.Sprintf("%s is not allowed to retitle PR #%d", event.GetComment().GetUser().GetLogin(), pullRequest.GetNumber()))
+			continue
+		}
+		pullRequest, _, err = v.client.PullRequests.Edit(ctx, t.owner, t.repo, pullRequest.GetNumber(), &github.PullRequest{
+			Title: github.String(command.Args),
+		})
+		if err != nil {
+			return target{}, fmt.Errorf("unable to retitle PR #%d: %w", event.GetIssue().GetNumber(), err)
+		}
+	}
+
+	t.headSHA = pullRequest.GetHead().GetSHA()
+	t.pulls = []*github.PullRequest{pullRequest}
+	return t, nil
+}
+
+// canRetitle returns true if the user is the author of the PR or can push to
+// the repo
+func (v *verifier) canRetitle(ctx context.Context, t target, pullRequest *github.PullRequest, user string) (bool, error) {
+	if user == pullRequest.GetUser().GetLogin() {
+		return true, nil
+	}
+	permission, _, err := v.client.Repositories.GetPermissionLevel(ctx, t.owner, t.repo, user)
+	if err != nil {
+		return false, fmt.Errorf("unable to get permissions of %s: %w", user, err)
+	}
+	switch permission.GetPermission() {
+	case "admin", "maintain", "write":
+		return true, nil
+	}
+	return false, nil
+}
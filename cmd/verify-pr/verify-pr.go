@@ -1,47 +1,440 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/google/go-github/v55/github"
 	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/config"
 	"github.com/konveyor/release-tools/pkg/pr"
 )
 
+var (
+	prTypesPath  = flag.String("pr-types", "", "Path to a PR type registry, defaults to the built-in PR types")
+	comment      = flag.Bool("comment", false, "Keep a comment on the PR describing verification failures")
+	commentUser  = flag.String("comment-author", "", "Login the token comments as, defaults to the authenticated user or "+action.DefaultCommentAuthor)
+	conventional = flag.Bool("allow-conventional", false, "Accept Conventional Commit prefixes like \"feat(ui): \" in PR titles")
+	checkSignOff = flag.Bool("check-signoff", false, "Require every commit to be signed off by its author (DCO)")
+	checkPrefix  = flag.Bool("check-commit-prefix", false, "Require every commit subject to have a PR type prefix")
+	checkBranch  = flag.Bool("check-base-branch", false, "Reject features and breaking changes targeting release-X.Y branches")
+	checkRun     = flag.Bool("check-run", false, "Publish the result as a GitHub check run")
+	checkName    = flag.String("check-name", "PR Title", "Name of the check run")
+	requireIssue = flag.Bool("require-linked-issue", false, "Require feature and bug fix PRs to close an open issue")
+	configPath   = flag.String("config", "", "Path to config.yaml, linked issues must be in one of its repos")
+	overrideLbl  = flag.String("release-branch-override-label", pr.ReleaseBranchOverrideLabel, "Label that allows features and breaking changes on release branches")
+)
+
+var commentMarker = action.CommentMarker("verify-pr")
+
+// verifier holds what is needed to verify PRs
+type verifier struct {
+	client   *github.Client
+	registry *pr.Registry
+	// repos that linked issues may be in, besides the repo of the PR, and
+	// their title style rules
+	repos []config.Repo
+}
+
+// verification is the outcome of verifying a single PR
+type verification struct {
+	number int
+	prType pr.PRType
+	scope  string
+	title  string
+	note   string
+	errs   []error
+	// warnings are reported without failing the verification
+	warnings []string
+}
+
 func main() {
+	flag.Parse()
+
+	v := &verifier{}
+	if *prTypesPath != "" {
+		prTypes, err := config.LoadPRTypes(*prTypesPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		v.registry = pr.NewRegistry(prTypes)
+	}
+	if *configPath != "" {
+		c, err := config.LoadConfig(*configPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		v.repos = c.Repos
+	}
 
 	ghContext, err := action.VarsFromEnv()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// Parse the event
-	eventFile, err := os.Open(ghContext.GithubEventPath)
-	if err != nil {
-		log.Fatal(fmt.Errorf("unable to load event file: %w", err))
+	ctx := context.Background()
+	if *comment || *checkRun || *checkSignOff || *checkPrefix || *requireIssue || needsClient(ghContext.GithubEventName) {
+		v.client = action.GetClient()
 	}
-	defer eventFile.Close()
 
-	var event github.PullRequestEvent
-	if err := json.NewDecoder(eventFile).Decode(&event); err != nil {
-		log.Fatal(fmt.Errorf("unable to unmarshal PullRequest event: %w", err))
+	author := *commentUser
+	if *comment && author == "" {
+		author = action.CommentAuthor(ctx, v.client)
 	}
 
-	// Check the title of the PR
-	prType, prTitle, err := pr.TypeFromTitle(*event.PullRequest.Title)
+	t, err := v.resolve(ctx, ghContext)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if len(t.pulls) == 0 {
+		fmt.Printf("Nothing to verify for %s event\n", ghContext.GithubEventName)
+		return
+	}
+
+	var results []verification
+	failed := false
+	for _, pullRequest := range t.pulls {
+		result := v.verify(ctx, pullRequest)
+		results = append(results, result)
+
+		for _, warning := range result.warnings {
+			action.WarningCommand(fmt.Sprintf("#%d: %s", result.number, warning))
+		}
+
+		if *comment {
+			if err := action.EnsureComment(ctx, v.client, t.owner, t.repo, pullRequest.GetNumber(),
+				author, commentMarker, commentBody(result)); err != nil {
+				action.WarningCommand(fmt.Sprintf("unable to update PR comment: %v", err))
+			}
+		}
+
+		if len(result.errs) > 0 {
+			failed = true
+			for _, err := range result.errs {
+				log.Printf("#%d: %v", result.number, err)
+			}
+			continue
+		}
+
+		fmt.Println()
+		fmt.Printf("PR: #%d\n", result.number)
+		fmt.Printf("PR type: %#q\n", result.prType)
+		fmt.Printf("PR scope: %#q\n", result.scope)
+		fmt.Printf("PR title: %#q\n", result.title)
+		fmt.Printf("PR release note: %#q\n", result.note)
+		fmt.Println()
+	}
+
+	if *checkRun {
+		if _, err := action.PublishCheckRun(ctx, v.client, t.owner, t.repo, checkRunResult(results, t.headSHA, ghContext.WorkflowPath(t.owner, t.repo))); err != nil {
+			action.WarningCommand(fmt.Sprintf("unable to publish check run: %v", err))
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
 
-	fmt.Println()
-	fmt.Printf("PR type: %#q\n", prType)
-	fmt.Printf("PR title: %#q\n", prTitle)
-	fmt.Println()
+	// Outputs describe a single PR, merge groups may have several
+	if len(results) != 1 {
+		return
+	}
+	result := results[0]
 
-	if err := action.SetOutput("pr_type", string(prType)); err != nil {
+	if err := action.SetOutput("pr_type", string(result.prType)); err != nil {
 		log.Printf("warning: unable to set pr_type output: %v", err)
 	}
+
+	if err := action.SetOutput("pr_scope", result.scope); err != nil {
+		log.Printf("warning: unable to set pr_scope output: %v", err)
+	}
+
+	// NONE means there is nothing for the changelog
+	note := result.note
+	if pr.IsNoneNote(note) {
+		note = ""
+	}
+	if err := action.SetOutput("release_note", note); err != nil {
+		log.Printf("warning: unable to set release_note output: %v", err)
+	}
+}
+
+// verify runs every check against the PR and collects the failures
+func (v *verifier) verify(ctx context.Context, pullRequest *github.PullRequest) verification {
+	result := verification{number: pullRequest.GetNumber()}
+
+	// Check the title of the PR
+	title, err := v.registry.ParseTitle(pullRequest.GetTitle(), *conventional)
+	result.prType, result.scope, result.title = title.Type, title.Scope, title.Title
+	if err != nil {
+		result.errs = append(result.errs, err)
+		return result
+	}
+
+	// Check the style of the title
+	var lintErrs []pr.LintProblem
+	for _, problem := range pr.LintTitle(result.title, v.titleLint(pullRequest)) {
+		if problem.Severity == pr.LintWarning {
+			result.warnings = append(result.warnings, problem.String())
+		} else {
+			lintErrs = append(lintErrs, problem)
+		}
+	}
+	if len(lintErrs) > 0 {
+		result.errs = append(result.errs, pr.TitleLintError{Problems: lintErrs})
+	}
+
+	// Check the PR type is allowed on the base branch
+	if *checkBranch {
+		var labels []string
+		for _, label := range pullRequest.Labels {
+			labels = append(labels, label.GetName())
+		}
+		if err := v.registry.VerifyBaseBranch(result.prType, pullRequest.GetBase().GetRef(), labels, *overrideLbl); err != nil {
+			result.errs = append(result.errs, err)
+		}
+	}
+
+	// Check the release note in the body of the PR
+	result.note, _ = pr.NoteFromBody(pullRequest.GetBody())
+	if err := v.registry.ValidateNote(result.prType, result.note); err != nil {
+		result.errs = append(result.errs, err)
+	}
+
+	// Check the commits of the PR
+	if *checkSignOff || *checkPrefix {
+		commits, err := v.listCommits(ctx, pullRequest)
+		if err != nil {
+			result.errs = append(result.errs, err)
+		} else if err := v.registry.VerifyCommits(commits, *checkSignOff, *checkPrefix, *conventional); err != nil {
+			result.errs = append(result.errs, err)
+		}
+	}
+
+	// Check the PR closes an issue
+	if *requireIssue && v.registry.LinkedIssueRequired(result.prType) {
+		if err := v.verifyLinkedIssues(ctx, result.prType, pullRequest); err != nil {
+			result.errs = append(result.errs, err)
+		}
+	}
+
+	return result
+}
+
+// verifyLinkedIssues checks that the PR closes at least one open issue in the
+// repo of the PR or one of the configured repos
+func (v *verifier) verifyLinkedIssues(ctx context.Context, prType pr.PRType, pullRequest *github.PullRequest) error {
+	owner := pullRequest.GetBase().GetRepo().GetOwner().GetLogin()
+	repo := pullRequest.GetBase().GetRepo().GetName()
+
+	var problems []string
+	for _, issue := range pr.LinkedIssues(pullRequest.GetBody(), owner, repo) {
+		if !issue.SameRepo(owner, repo) && !v.configuredRepo(issue) {
+			problems = append(problems, fmt.Sprintf("%s is not in a repository managed by konveyor/release-tools", issue))
+			continue
+		}
+
+		found, resp, err := v.client.Issues.Get(ctx, issue.Org, issue.Repo, issue.Number)
+		if err != nil {
+			if resp != nil && resp.StatusCode == 404 {
+				problems = append(problems, fmt.Sprintf("%s does not exist", issue))
+				continue
+			}
+			return fmt.Errorf("unable to get linked issue %s: %w", issue, err)
+		}
+
+		switch {
+		case found.IsPullRequest():
+			problems = append(problems, fmt.Sprintf("%s is a pull request, not an issue", issue))
+		case found.GetState() != "open":
+			problems = append(problems, fmt.Sprintf("%s is %s", issue, found.GetState()))
+		default:
+			return nil
+		}
+	}
+
+	return pr.LinkedIssueError{PRType: prType, Problems: problems}
+}
+
+// titleLint returns the title style rules of the repo of the PR, nil if the
+// repo has none
+func (v *verifier) titleLint(pullRequest *github.PullRequest) *config.TitleLintConfig {
+	owner := pullRequest.GetBase().GetRepo().GetOwner().GetLogin()
+	repo := pullRequest.GetBase().GetRepo().GetName()
+	for _, r := range v.repos {
+		if strings.EqualFold(r.Org, owner) && strings.EqualFold(r.Repo, repo) {
+			return r.TitleLint
+		}
+	}
+	return nil
+}
+
+// configuredRepo returns true if the issue is in one of the configured repos
+func (v *verifier) configuredRepo(issue pr.IssueRef) bool {
+	for _, r := range v.repos {
+		if issue.SameRepo(r.Org, r.Repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// listCommits returns the commits of the PR
+func (v *verifier) listCommits(ctx context.Context, pullRequest *github.PullRequest) ([]pr.Commit, error) {
+	owner := pullRequest.GetBase().GetRepo().GetOwner().GetLogin()
+	repo := pullRequest.GetBase().GetRepo().GetName()
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	var commits []pr.Commit
+	for {
+		repoCommits, resp, err := v.client.PullRequests.ListCommits(ctx, owner, repo, pullRequest.GetNumber(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list PR commits: %w", err)
+		}
+
+		for _, c := range repoCommits {
+			commits = append(commits, pr.Commit{
+				SHA:         c.GetSHA(),
+				Message:     c.GetCommit().GetMessage(),
+				AuthorName:  c.GetCommit().GetAuthor().GetName(),
+				AuthorEmail: c.GetCommit().GetAuthor().GetEmail(),
+				Parents:     len(c.Parents),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return commits, nil
+}
+
+// checkRunResult renders the check run for the verifications, one per PR,
+// annotating the file at path. PR titles and bodies are not files, without a
+// file to show them on the summary is all there is.
+func checkRunResult(results []verification, headSHA, path string) action.CheckRun {
+	check := action.CheckRun{
+		Name:       *checkName,
+		HeadSHA:    headSHA,
+		Conclusion: "success",
+	}
+
+	var summary strings.Builder
+	problems := 0
+	for i, result := range results {
+		if len(results) > 1 {
+			if i > 0 {
+				summary.WriteString("\n")
+			}
+			fmt.Fprintf(&summary, "### #%d\n\n", result.number)
+		}
+		summary.WriteString("| | |\n|---|---|\n")
+		fmt.Fprintf(&summary, "| **Type** | `%s` |\n", result.prType)
+		if result.scope != "" {
+			fmt.Fprintf(&summary, "| **Scope** | `%s` |\n", result.scope)
+		}
+		fmt.Fprintf(&summary, "| **Title** | %s |\n", result.title)
+
+		if len(result.warnings) > 0 {
+			summary.WriteString("\n:warning: **Warnings**\n\n")
+			for _, warning := range result.warnings {
+				fmt.Fprintf(&summary, "- %s\n", warning)
+			}
+		}
+
+		for _, err := range result.errs {
+			summary.WriteString("\n---\n\n")
+			summary.WriteString(err.Error())
+			summary.WriteString("\n")
+		}
+		problems += len(result.errs)
+		if path != "" {
+			check.Annotations = append(check.Annotations, annotations(result, path)...)
+		}
+	}
+
+	switch {
+	case problems > 0:
+		check.Conclusion = "failure"
+		check.Title = fmt.Sprintf("%d problem(s) found", problems)
+	case len(results) == 1:
+		check.Title = fmt.Sprintf("%s: %s", results[0].prType, results[0].title)
+	default:
+		check.Title = fmt.Sprintf("%d PRs verified", len(results))
+	}
+	check.Summary = summary.String()
+	return check
+}
+
+// annotations returns an annotation on the file at path per failed rule and
+// per warning of the verification
+func annotations(result verification, path string) []action.Annotation {
+	annotation := func(level, rule, message string) action.Annotation {
+		return action.Annotation{
+			Path:      path,
+			StartLine: 1,
+			EndLine:   1,
+			Level:     level,
+			Title:     fmt.Sprintf("#%d %s", result.number, rule),
+			Message:   message,
+		}
+	}
+
+	var list []action.Annotation
+	for _, err := range result.errs {
+		if lint, ok := err.(pr.TitleLintError); ok {
+			for _, problem := range lint.Problems {
+				list = append(list, annotation("failure", string(problem.Rule), problem.Message))
+			}
+			continue
+		}
+		list = append(list, annotation("failure", ruleName(err), err.Error()))
+	}
+	for _, warning := range result.warnings {
+		list = append(list, annotation("warning", "title style", warning))
+	}
+	return list
+}
+
+// ruleName returns the name of the rule the verification error is about
+func ruleName(err error) string {
+	switch err.(type) {
+	case pr.PRTypeError, pr.PRTypeUsedEmojiError, pr.ConventionalCommitError:
+		return "PR type"
+	case pr.ReleaseNoteMissingError, pr.ReleaseNoteNoneError:
+		return "release note"
+	case pr.ReleaseBranchError:
+		return "base branch"
+	case pr.LinkedIssueError:
+		return "linked issue"
+	case pr.CommitsError:
+		return "commits"
+	}
+	return "verification"
+}
+
+// commentBody renders the PR comment for the verification, empty when there
+// is nothing to report
+func commentBody(result verification) string {
+	if len(result.errs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(":x: **This PR did not pass verification.**\n")
+	for _, err := range result.errs {
+		b.WriteString("\n---\n\n")
+		b.WriteString(err.Error())
+		b.WriteString("\n")
+	}
+	b.WriteString("\n---\n\nThis comment is updated automatically and removed once the PR passes verification.\n")
+	return b.String()
 }
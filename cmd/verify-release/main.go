@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/registry"
+	"github.com/konveyor/release-tools/pkg/release"
+	"github.com/konveyor/release-tools/pkg/semver"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	configPath   = flag.String("config", "pkg/config/config.yaml", "Path to config.yaml, the repos and images of a Konveyor release")
+	version      = flag.String("version", "", "Semantic version of the Konveyor release, e.g. v0.8.0 or v0.8.0-beta.1")
+	registryHost = flag.String("registry", "", "Registry host replacing the one of every image, e.g. localhost:5000")
+	plainHTTP    = flag.Bool("plain-http", false, "Talk to the registry over http, e.g. for a local registry")
+	reportFlags  = action.NewReportFlags(action.FormatMarkdown, "Format of the report: markdown or json")
+)
+
+func main() {
+	flag.Parse()
+	if err := reportFlags.SetupLogging(); err != nil {
+		logrus.WithError(err).Fatal("Invalid log level")
+	}
+
+	v, err := semver.Parse(*version)
+	if err != nil {
+		logrus.WithError(err).Fatal("Invalid version")
+	}
+
+	c, err := config.LoadConfig(*configPath)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load config")
+	}
+
+	ctx := context.Background()
+	verifier := release.NewVerifier(action.GetClient(), registry.NewClient(registry.Options{
+		Host:      *registryHost,
+		PlainHTTP: *plainHTTP,
+		Username:  os.Getenv("REGISTRY_USERNAME"),
+		Password:  os.Getenv("REGISTRY_PASSWORD"),
+	}))
+
+	report := &release.Report{Version: v.String()}
+	for _, r := range c.ReleaseRepos() {
+		logrus.WithField("repo", r.Org+"/"+r.Repo).Debug("Verifying")
+		report.Repos = append(report.Repos, verifier.Verify(ctx, r.Org, r.Repo, r.Images, v))
+	}
+
+	body, err := release.RenderReport(report, *reportFlags.Format)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to render the verification report")
+	}
+	if err := reportFlags.Write([]byte(body)); err != nil {
+		logrus.WithError(err).Fatal("Failed to write the verification report")
+	}
+
+	if err := action.SetOutput("verified", fmt.Sprint(report.OK())); err != nil {
+		logrus.WithError(err).Warn("Unable to set verified output")
+	}
+	failed := 0
+	for _, r := range report.Repos {
+		if !r.OK() {
+			failed++
+			action.ErrorCommand(fmt.Sprintf("%s %s/%s is not fully published", v, r.Org, r.Repo))
+		}
+	}
+	if failed > 0 {
+		logrus.Fatalf("%s is not fully published in %d repos", v, failed)
+	}
+	action.NoticeCommand(fmt.Sprintf("Konveyor %s is published in %d repos", v, len(report.Repos)))
+}
@@ -8,6 +8,7 @@ require (
 	github.com/google/go-github/v55 v55.0.0
 	github.com/sirupsen/logrus v1.9.3
 	github.com/wneessen/go-mail v0.4.1
+	golang.org/x/mod v0.13.0
 	golang.org/x/oauth2 v0.12.0
 	gopkg.in/yaml.v2 v2.4.0
 	sigs.k8s.io/yaml v1.3.0
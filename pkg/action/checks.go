@@ -0,0 +1,106 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// The Checks API accepts at most 50 annotations per request
+const maxAnnotationsPerRequest = 50
+
+// Annotation points at lines of a file in a check run, the file must exist at
+// the head commit for GitHub to show it.
+type Annotation struct {
+	Path      string
+	StartLine int
+	EndLine   int
+	// Level is one of notice, warning or failure
+	Level   string
+	Title   string
+	Message string
+}
+
+// CheckRun is the result to publish as a GitHub check run.
+type CheckRun struct {
+	Name    string
+	HeadSHA string
+	// Conclusion is one of success, failure, neutral, cancelled, skipped,
+	// timed_out or action_required. The check run is left in progress when
+	// empty.
+	Conclusion  string
+	Title       string
+	Summary     string
+	Annotations []Annotation
+}
+
+// PublishCheckRun creates a new check run. Updating a previous run of the
+// same commit would keep its annotations, a new run replaces it as the latest
+// run of the check instead.
+func PublishCheckRun(ctx context.Context, client *github.Client, owner, repo string, check CheckRun) (*github.CheckRun, error) {
+	status := "completed"
+	var conclusion *string
+	if check.Conclusion == "" {
+		status = "in_progress"
+	} else {
+		conclusion = github.String(check.Conclusion)
+	}
+
+	batches := annotationBatches(check.Annotations)
+	output := &github.CheckRunOutput{
+		Title:       github.String(check.Title),
+		Summary:     github.String(check.Summary),
+		Annotations: batches[0],
+	}
+
+	run, _, err := client.Checks.CreateCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+		Name:       check.Name,
+		HeadSHA:    check.HeadSHA,
+		Status:     github.String(status),
+		Conclusion: conclusion,
+		Output:     output,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create check run: %w", err)
+	}
+
+	// Annotations beyond the first batch are appended by further updates
+	for _, batch := range batches[1:] {
+		_, _, err = client.Checks.UpdateCheckRun(ctx, owner, repo, run.GetID(), github.UpdateCheckRunOptions{
+			Name: check.Name,
+			Output: &github.CheckRunOutput{
+				Title:       github.String(check.Title),
+				Summary:     github.String(check.Summary),
+				Annotations: batch,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add check run annotations: %w", err)
+		}
+	}
+
+	return run, nil
+}
+
+// annotationBatches splits annotations into batches the Checks API accepts,
+// there is always at least one (possibly empty) batch
+func annotationBatches(annotations []Annotation) [][]*github.CheckRunAnnotation {
+	batches := [][]*github.CheckRunAnnotation{{}}
+	for _, a := range annotations {
+		last := len(batches) - 1
+		if len(batches[last]) == maxAnnotationsPerRequest {
+			batches = append(batches, []*github.CheckRunAnnotation{})
+			last++
+		}
+		batches[last] = append(batches[last], &github.CheckRunAnnotation{
+			Path:            github.String(a.Path),
+			StartLine:       github.Int(a.StartLine),
+			EndLine:         github.Int(a.EndLine),
+			AnnotationLevel: github.String(a.Level),
+			Title:           github.String(a.Title),
+			Message:         github.String(a.Message),
+		})
+	}
+	return batches
+}
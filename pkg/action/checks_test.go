@@ -0,0 +1,48 @@
+package action
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAnnotationBatches(t *testing.T) {
+	testCases := []struct {
+		annotations int
+		expected    []int
+	}{
+		{annotations: 0, expected: []int{0}},
+		{annotations: 1, expected: []int{1}},
+		{annotations: 50, expected: []int{50}},
+		{annotations: 51, expected: []int{50, 1}},
+		{annotations: 120, expected: []int{50, 50, 20}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc.annotations), func(t *testing.T) {
+			var annotations []Annotation
+			for i := 0; i < tc.annotations; i++ {
+				annotations = append(annotations, Annotation{Path: ".github", StartLine: 1, EndLine: 1, Level: "failure", Message: fmt.Sprint(i)})
+			}
+
+			batches := annotationBatches(annotations)
+			var sizes []int
+			for _, batch := range batches {
+				sizes = append(sizes, len(batch))
+			}
+			if fmt.Sprint(sizes) != fmt.Sprint(tc.expected) {
+				t.Fatalf("Expected batches of %v but got %v", tc.expected, sizes)
+			}
+
+			// Annotations keep their order across batches
+			n := 0
+			for _, batch := range batches {
+				for _, a := range batch {
+					if a.GetMessage() != fmt.Sprint(n) {
+						t.Fatalf("Expected annotation %d but got %s", n, a.GetMessage())
+					}
+					n++
+				}
+			}
+		})
+	}
+}
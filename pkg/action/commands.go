@@ -5,6 +5,8 @@ package action
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 )
 
 func sendCommand(command, message string) {
@@ -29,6 +31,7 @@ func ErrorCommand(message string) {
 
 // SetOutput writes a key=value pair to the GITHUB_OUTPUT file so that
 // downstream steps and jobs can consume it via steps.<id>.outputs.<key>.
+// Multiline values are written using the heredoc style delimiter syntax.
 func SetOutput(key, value string) error {
 	outputFile := os.Getenv("GITHUB_OUTPUT")
 	if outputFile == "" {
@@ -39,6 +42,11 @@ func SetOutput(key, value string) error {
 		return fmt.Errorf("unable to open GITHUB_OUTPUT: %w", err)
 	}
 	defer f.Close()
-	_, err = fmt.Fprintf(f, "%s=%s\n", key, value)
+	if !strings.Contains(value, "\n") {
+		_, err = fmt.Fprintf(f, "%s=%s\n", key, value)
+		return err
+	}
+	delimiter := fmt.Sprintf("ghadelimiter_%d", time.Now().UnixNano())
+	_, err = fmt.Fprintf(f, "%s<<%s\n%s\n%s\n", key, delimiter, value, delimiter)
 	return err
 }
@@ -0,0 +1,96 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// DefaultCommentAuthor is the login the GITHUB_TOKEN of a workflow comments
+// as
+const DefaultCommentAuthor = "github-actions[bot]"
+
+// CommentMarker returns the hidden HTML marker used to find a comment again.
+func CommentMarker(name string) string {
+	return fmt.Sprintf("<!-- konveyor/release-tools: %s -->", name)
+}
+
+// CommentAuthor returns the login the client comments as. Installation tokens,
+// like the GITHUB_TOKEN of a workflow, cannot get their user so they are
+// assumed to be DefaultCommentAuthor.
+func CommentAuthor(ctx context.Context, client *github.Client) string {
+	user, _, err := client.Users.Get(ctx, "")
+	if err != nil || user.GetLogin() == "" {
+		return DefaultCommentAuthor
+	}
+	return user.GetLogin()
+}
+
+// EnsureComment keeps a single comment of author tagged with marker on an
+// issue or PR. The comment is created or updated to contain body, or deleted
+// when body is empty. Comments of other users are never touched, even when
+// they contain the marker. This is the Go version of ensureComment in
+// reconcile-issue.yaml.
+func EnsureComment(ctx context.Context, client *github.Client, owner, repo string, number int, author, marker, body string) error {
+	existing, err := findComment(ctx, client, owner, repo, number, author, marker)
+	if err != nil {
+		return err
+	}
+
+	if body == "" {
+		if existing == nil {
+			return nil
+		}
+		if _, err := client.Issues.DeleteComment(ctx, owner, repo, existing.GetID()); err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+		return nil
+	}
+
+	body = marker + "\n" + body
+	if existing == nil {
+		if _, _, err := client.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: github.String(body)}); err != nil {
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+		return nil
+	}
+
+	if existing.GetBody() == body {
+		return nil
+	}
+	if _, _, err := client.Issues.EditComment(ctx, owner, repo, existing.GetID(), &github.IssueComment{Body: github.String(body)}); err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+	return nil
+}
+
+// findComment returns the first comment of author containing marker, or nil
+func findComment(ctx context.Context, client *github.Client, owner, repo string, number int, author, marker string) (*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	for {
+		comments, resp, err := client.Issues.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments: %w", err)
+		}
+
+		for _, comment := range comments {
+			if strings.EqualFold(comment.GetUser().GetLogin(), author) && strings.Contains(comment.GetBody(), marker) {
+				return comment, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,61 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestEnsureComment(t *testing.T) {
+	marker := CommentMarker("test")
+
+	var edited []int64
+	var created int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/konveyor/operator/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			created++
+			fmt.Fprint(w, `{"id": 3}`)
+			return
+		}
+		json.NewEncoder(w).Encode([]*github.IssueComment{
+			// A user quoting the marker is not the bot comment
+			{ID: github.Int64(1), Body: github.String(marker + "\nquoted"), User: &github.User{Login: github.String("someone")}},
+			{ID: github.Int64(2), Body: github.String(marker + "\nold"), User: &github.User{Login: github.String("konveyor-ci-bot[bot]")}},
+		})
+	})
+	mux.HandleFunc("/repos/konveyor/operator/issues/comments/", func(w http.ResponseWriter, r *http.Request) {
+		var id int64
+		fmt.Sscanf(r.URL.Path, "/repos/konveyor/operator/issues/comments/%d", &id)
+		edited = append(edited, id)
+		fmt.Fprint(w, `{}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	ctx := context.Background()
+
+	if err := EnsureComment(ctx, client, "konveyor", "operator", 1, "konveyor-ci-bot[bot]", marker, "new"); err != nil {
+		t.Fatalf("EnsureComment() error = %v", err)
+	}
+	if len(edited) != 1 || edited[0] != 2 || created != 0 {
+		t.Errorf("EnsureComment() edited %v and created %d, want the bot comment 2 edited", edited, created)
+	}
+
+	// Another author has no comment yet
+	edited = nil
+	if err := EnsureComment(ctx, client, "konveyor", "operator", 1, DefaultCommentAuthor, marker, "new"); err != nil {
+		t.Fatalf("EnsureComment() error = %v", err)
+	}
+	if len(edited) != 0 || created != 1 {
+		t.Errorf("EnsureComment() edited %v and created %d, want a new comment", edited, created)
+	}
+}
@@ -2,6 +2,7 @@ package action
 
 import (
 	"fmt"
+	"strings"
 
 	env "github.com/Netflix/go-env"
 )
@@ -17,6 +18,20 @@ type GitHubVariables struct {
 
 	GithubEventName string `env:"GITHUB_EVENT_NAME"`
 	GithubEventPath string `env:"GITHUB_EVENT_PATH"`
+	// GithubWorkflowRef is the workflow file of the run, e.g.
+	// octo-org/octo-repo/.github/workflows/my-workflow.yml@refs/heads/main
+	GithubWorkflowRef string `env:"GITHUB_WORKFLOW_REF"`
+}
+
+// WorkflowPath returns the path of the workflow file of the run in the repo,
+// "" when the workflow is not in the repo
+func (v GitHubVariables) WorkflowPath(owner, repo string) string {
+	ref, _, _ := strings.Cut(v.GithubWorkflowRef, "@")
+	prefix := owner + "/" + repo + "/"
+	if len(ref) <= len(prefix) || !strings.EqualFold(ref[:len(prefix)], prefix) {
+		return ""
+	}
+	return ref[len(prefix):]
 }
 
 // VarsFromEnv retrieves GitHubVariables struct from the environment
@@ -38,3 +38,16 @@ func TestVarsFromEnv(t *testing.T) {
 		t.Error("Expected GithubEventPath field in GitHubVariables struct to be \"/foo/bar/baz.json\"")
 	}
 }
+
+func TestWorkflowPath(t *testing.T) {
+	v := GitHubVariables{GithubWorkflowRef: "konveyor/tackle2-hub/.github/workflows/pr-checks.yml@refs/pull/12/merge"}
+	if got := v.WorkflowPath("konveyor", "tackle2-hub"); got != ".github/workflows/pr-checks.yml" {
+		t.Errorf("WorkflowPath() = %q, want the workflow file", got)
+	}
+	if got := v.WorkflowPath("konveyor", "tackle2-ui"); got != "" {
+		t.Errorf("WorkflowPath() of another repo = %q, want none", got)
+	}
+	if got := (GitHubVariables{}).WorkflowPath("konveyor", "tackle2-hub"); got != "" {
+		t.Errorf("WorkflowPath() without a workflow ref = %q, want none", got)
+	}
+}
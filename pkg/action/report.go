@@ -0,0 +1,75 @@
+package action
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Formats of the reports written by the release commands
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+)
+
+// LogFlags are the flags of a command logging to stderr
+type LogFlags struct {
+	LogLevel *string
+}
+
+// NewLogFlags registers the log-level flag
+func NewLogFlags() *LogFlags {
+	return &LogFlags{
+		LogLevel: flag.String("log-level", "info", "Log level (debug, info, warn, error)"),
+	}
+}
+
+// SetupLogging sets the log level and keeps stdout for the output of the
+// command
+func (f *LogFlags) SetupLogging() error {
+	level, err := logrus.ParseLevel(*f.LogLevel)
+	if err != nil {
+		return err
+	}
+	logrus.SetLevel(level)
+	logrus.SetOutput(os.Stderr)
+	return nil
+}
+
+// ReportFlags are the flags of a command writing a report to stdout or to a
+// file, logging to stderr
+type ReportFlags struct {
+	Format *string
+	Output *string
+	*LogFlags
+}
+
+// NewReportFlags registers the format, output and log-level flags
+func NewReportFlags(defaultFormat, formatUsage string) *ReportFlags {
+	return &ReportFlags{
+		Format:   flag.String("format", defaultFormat, formatUsage),
+		Output:   flag.String("output", "", "File to write the report to, defaults to stdout"),
+		LogFlags: NewLogFlags(),
+	}
+}
+
+// Write writes the report to the output file, or to stdout when there is none
+func (f *ReportFlags) Write(report []byte) error {
+	if *f.Output == "" {
+		_, err := os.Stdout.Write(report)
+		return err
+	}
+	return os.WriteFile(*f.Output, report, 0644)
+}
+
+// MarshalJSON renders a report as indented JSON ending with a newline
+func MarshalJSON(v interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,28 @@
+package action
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReportFlagsWrite(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "report.json")
+	flags := &ReportFlags{Output: &output}
+
+	data, err := MarshalJSON(map[string]int{"pending": 2})
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+	if err := flags.Write(data); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\n  \"pending\": 2\n}\n"; string(got) != want {
+		t.Errorf("Write() wrote %q, want %q", got, want)
+	}
+}
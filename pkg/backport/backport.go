@@ -0,0 +1,138 @@
+package backport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/pr"
+)
+
+// Label prefixes of the cherry-pick bot
+const (
+	// CherryPickLabelPrefix asks to cherry-pick a PR to a release branch once
+	// merged, e.g. "cherry-pick/release-0.8"
+	CherryPickLabelPrefix = "cherry-pick/"
+	// FailedLabelPrefix marks the PRs that could not be cherry-picked to a
+	// release branch, e.g. "cherry-pick-failed/release-0.8"
+	FailedLabelPrefix = "cherry-pick-failed/"
+)
+
+// TargetBranches returns the release branches the labels ask to cherry-pick
+// to, in label order
+func TargetBranches(labels []string) []string {
+	var branches []string
+	seen := make(map[string]bool)
+	for _, label := range labels {
+		if !strings.HasPrefix(label, CherryPickLabelPrefix) {
+			continue
+		}
+		branch := strings.TrimPrefix(label, CherryPickLabelPrefix)
+		if !pr.IsReleaseBranch(branch) || seen[branch] {
+			continue
+		}
+		seen[branch] = true
+		branches = append(branches, branch)
+	}
+	return branches
+}
+
+// MergedCommits returns the number of commits the merge of the PR put on its
+// base branch, ending at the merge commit SHA: the PR commits for a rebase
+// merge, 1 for a merge commit or a squash merge
+func MergedCommits(ctx context.Context, client *github.Client, org, repo string, pull *github.PullRequest) (int, error) {
+	commits := pull.GetCommits()
+	if commits <= 1 {
+		return 1, nil
+	}
+	merge, _, err := client.Repositories.GetCommit(ctx, org, repo, pull.GetMergeCommitSHA(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get merge commit %s: %w", pull.GetMergeCommitSHA(), err)
+	}
+	if len(merge.Parents) != 1 {
+		return 1, nil
+	}
+
+	// The parent of a squash merge was on the base branch before the PR, the
+	// parent of a rebase merge is one of the PR commits
+	pulls, _, err := client.PullRequests.ListPullRequestsWithCommit(ctx, org, repo, merge.Parents[0].GetSHA(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list PRs of commit %s: %w", merge.Parents[0].GetSHA(), err)
+	}
+	for _, p := range pulls {
+		if p.GetNumber() == pull.GetNumber() {
+			return commits, nil
+		}
+	}
+	return 1, nil
+}
+
+// Revision returns the revision of the commits ending at sha, sha itself for
+// a single commit
+func Revision(sha string, commits int) string {
+	if commits <= 1 {
+		return sha
+	}
+	return fmt.Sprintf("%s~%d..%s", sha, commits, sha)
+}
+
+// FailedLabel returns the label of the PRs that could not be cherry-picked to
+// the branch
+func FailedLabel(branch string) string {
+	return FailedLabelPrefix + branch
+}
+
+// BranchName returns the branch of the backport PR of the PR to the release
+// branch, which pr.BackportOf recognizes
+func BranchName(number int, branch string) string {
+	return fmt.Sprintf("cherry-pick-pr%d-%s", number, branch)
+}
+
+// Title returns the title of the backport PR, keeping the PR type prefix of
+// the original PR first
+func Title(title, branch string) string {
+	return fmt.Sprintf("%s [%s]", title, branch)
+}
+
+// Body returns the description of the backport PR
+func Body(number int, body string) string {
+	b := fmt.Sprintf("Cherry-pick of #%d", number)
+	if body = strings.TrimSpace(body); body != "" {
+		b += "\n\n" + body
+	}
+	return b
+}
+
+// SuccessComment returns the comment posted on the PR once cherry-picked
+func SuccessComment(branch, url string) string {
+	return fmt.Sprintf("PR cherry-picked to branch __%s__. Backport PR: %s", branch, url)
+}
+
+// ConflictComment returns the comment posted on the PR when the cherry-pick
+// of the revision conflicts, with the steps to backport it by hand
+func ConflictComment(number int, branch, revision string, conflicts []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Failed to cherry-pick this PR to branch __%s__", branch)
+	if len(conflicts) == 0 {
+		b.WriteString(".\n")
+	} else {
+		b.WriteString(", these files conflict:\n")
+		for _, file := range conflicts {
+			fmt.Fprintf(&b, "* `%s`\n", file)
+		}
+	}
+
+	name := BranchName(number, branch)
+	b.WriteString("\nTo backport it by hand:\n")
+	b.WriteString("```sh\n")
+	fmt.Fprintf(&b, "git fetch origin %s\n", branch)
+	fmt.Fprintf(&b, "git checkout -b %s origin/%s\n", name, branch)
+	fmt.Fprintf(&b, "git cherry-pick -x -s %s\n", revision)
+	b.WriteString("# resolve the conflicts, then\n")
+	b.WriteString("git add <files> && git cherry-pick --continue\n")
+	fmt.Fprintf(&b, "git push origin %s\n", name)
+	b.WriteString("```\n")
+	fmt.Fprintf(&b, "\nThen open a PR to __%s__ with `Cherry-pick of #%d` in its description, and remove the `%s` label.\n", branch, number, FailedLabel(branch))
+	return b.String()
+}
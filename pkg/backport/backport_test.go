@@ -0,0 +1,269 @@
+package backport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/pr"
+)
+
+func TestTargetBranches(t *testing.T) {
+	labels := []string{
+		"kind/bug",
+		"cherry-pick/release-0.7",
+		"cherry-pick/main",
+		"cherry-pick/release-0.8",
+		"cherry-pick/release-0.7",
+		"cherry-pick-failed/release-0.6",
+	}
+	want := []string{"release-0.7", "release-0.8"}
+	if got := TargetBranches(labels); !reflect.DeepEqual(got, want) {
+		t.Errorf("TargetBranches() = %v, want %v", got, want)
+	}
+}
+
+func TestBackportIsRecognized(t *testing.T) {
+	if number, ok := pr.BackportOf(BranchName(123, "release-0.8"), ""); !ok || number != 123 {
+		t.Errorf("expected the branch to be recognized as a backport of #123, got %d, %v", number, ok)
+	}
+	if number, ok := pr.BackportOf("fix", Body(123, "Fixes #100")); !ok || number != 123 {
+		t.Errorf("expected the body to be recognized as a backport of #123, got %d, %v", number, ok)
+	}
+}
+
+func TestConflictComment(t *testing.T) {
+	comment := ConflictComment(123, "release-0.8", "abc123", []string{"go.mod", "pkg/a.go"})
+	for _, want := range []string{
+		"branch __release-0.8__, these files conflict:\n* `go.mod`\n* `pkg/a.go`\n",
+		"git checkout -b cherry-pick-pr123-release-0.8 origin/release-0.8\n",
+		"git cherry-pick -x -s abc123\n",
+		"remove the `cherry-pick-failed/release-0.8` label",
+	} {
+		if !strings.Contains(comment, want) {
+			t.Errorf("expected comment to contain %q, got:\n%s", want, comment)
+		}
+	}
+}
+
+func TestCherryPick(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// origin has a release branch and commits on main, the first one applies
+	// to the release branch, the second one conflicts and the last two are
+	// the commits of a rebase merge
+	origin := t.TempDir()
+	git(t, origin, "init", "-q", "-b", "main")
+	write(t, origin, "a.txt", "one\n")
+	write(t, origin, "b.txt", "one\n")
+	git(t, origin, "add", ".")
+	git(t, origin, "commit", "-q", "-m", "init")
+	git(t, origin, "branch", "release-0.8")
+
+	write(t, origin, "a.txt", "two\n")
+	git(t, origin, "commit", "-q", "-am", "change a")
+	clean := strings.TrimSpace(git(t, origin, "rev-parse", "HEAD"))
+
+	write(t, origin, "b.txt", "two\n")
+	git(t, origin, "commit", "-q", "-am", "change b")
+	conflicting := strings.TrimSpace(git(t, origin, "rev-parse", "HEAD"))
+
+	write(t, origin, "c.txt", "one\n")
+	git(t, origin, "add", "c.txt")
+	git(t, origin, "commit", "-q", "-m", "add c")
+	write(t, origin, "d.txt", "one\n")
+	git(t, origin, "add", "d.txt")
+	git(t, origin, "commit", "-q", "-m", "add d")
+	rebased := strings.TrimSpace(git(t, origin, "rev-parse", "HEAD"))
+
+	git(t, origin, "checkout", "-q", "release-0.8")
+	write(t, origin, "b.txt", "three\n")
+	git(t, origin, "commit", "-q", "-am", "change b on release")
+	git(t, origin, "checkout", "-q", "main")
+
+	clone := t.TempDir()
+	git(t, clone, "clone", "-q", origin, ".")
+	git(t, clone, "config", "user.name", "test")
+	git(t, clone, "config", "user.email", "test@example.com")
+	g := NewGit(clone)
+
+	if err := g.CherryPick(clean, "release-0.8", BranchName(1, "release-0.8")); err != nil {
+		t.Fatalf("CherryPick() = %v", err)
+	}
+	message := git(t, clone, "log", "-1", "--format=%B")
+	if got := pr.CherryPickedFrom(message); len(got) != 1 || got[0] != clean {
+		t.Errorf("expected the cherry-pick to record %s, got message:\n%s", clean, message)
+	}
+
+	err := g.CherryPick(conflicting, "release-0.8", BranchName(2, "release-0.8"))
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+	if !reflect.DeepEqual(conflict.Files, []string{"b.txt"}) {
+		t.Errorf("expected b.txt to conflict, got %v", conflict.Files)
+	}
+	if status := git(t, clone, "status", "--porcelain"); status != "" {
+		t.Errorf("expected a clean clone after a conflict, got:\n%s", status)
+	}
+
+	if err := g.CherryPickCommits(rebased, 2, "release-0.8", BranchName(3, "release-0.8")); err != nil {
+		t.Fatalf("CherryPickCommits() = %v", err)
+	}
+	if subjects := git(t, clone, "log", "-3", "--format=%s"); subjects != "add d\nadd c\nchange b on release\n" {
+		t.Errorf("expected both commits on the release branch, got:\n%s", subjects)
+	}
+}
+
+func TestMergedCommits(t *testing.T) {
+	const merge = "1111111111111111111111111111111111111111"
+	mux := http.NewServeMux()
+	// The parent of the squash merge is from another PR, the parent of the
+	// rebase merge is from the PR
+	mux.HandleFunc("/repos/konveyor/squashed/commits/"+merge, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"sha": "`+merge+`", "parents": [{"sha": "aaa"}]}`)
+	})
+	mux.HandleFunc("/repos/konveyor/squashed/commits/aaa/pulls", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"number": 9}]`)
+	})
+	mux.HandleFunc("/repos/konveyor/rebased/commits/"+merge, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"sha": "`+merge+`", "parents": [{"sha": "bbb"}]}`)
+	})
+	mux.HandleFunc("/repos/konveyor/rebased/commits/bbb/pulls", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"number": 10}]`)
+	})
+	mux.HandleFunc("/repos/konveyor/merged/commits/"+merge, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"sha": "`+merge+`", "parents": [{"sha": "aaa"}, {"sha": "bbb"}]}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	tests := []struct {
+		repo    string
+		commits int
+		want    int
+	}{
+		{repo: "single", commits: 1, want: 1},
+		{repo: "squashed", commits: 3, want: 1},
+		{repo: "rebased", commits: 3, want: 3},
+		{repo: "merged", commits: 3, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repo, func(t *testing.T) {
+			pull := &github.PullRequest{Number: github.Int(10), Commits: github.Int(tt.commits), MergeCommitSHA: github.String(merge)}
+			got, err := MergedCommits(context.Background(), client, "konveyor", tt.repo, pull)
+			if err != nil {
+				t.Fatalf("MergedCommits() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MergedCommits() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+	if got := Revision(merge, 3); got != merge+"~3.."+merge {
+		t.Errorf("Revision() = %q, want the range of 3 commits", got)
+	}
+}
+
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	now := time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC)
+	r := &Reporter{now: func() time.Time { return now }}
+	merged := &github.PullRequest{
+		Number:   github.Int(10),
+		Title:    github.String(":bug: Fix crash"),
+		HTMLURL:  github.String("https://github.com/konveyor/operator/pull/10"),
+		MergedAt: &github.Timestamp{Time: now.AddDate(0, 0, -5)},
+	}
+	backportPR := func(state string, mergedAt *github.Timestamp) *github.PullRequest {
+		return &github.PullRequest{
+			Number:   github.Int(11),
+			State:    github.String(state),
+			HTMLURL:  github.String("https://github.com/konveyor/operator/pull/11"),
+			MergedAt: mergedAt,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		backport    *github.PullRequest
+		failed      bool
+		wantState   string
+		wantPending int
+	}{
+		{name: "no backport", wantState: StateMissing, wantPending: 5},
+		{name: "failed cherry-pick", failed: true, wantState: StateConflict, wantPending: 5},
+		{name: "open backport", backport: backportPR("open", nil), failed: true, wantState: StateOpen, wantPending: 5},
+		{name: "merged backport", backport: backportPR("closed", &github.Timestamp{Time: now}), wantState: StateMerged},
+		{name: "closed backport", backport: backportPR("closed", nil), wantState: StateClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := r.status("konveyor", "operator", merged, "release-0.8", tt.backport, tt.failed)
+			if s.State != tt.wantState || s.PendingDays != tt.wantPending {
+				t.Errorf("status() = %s, %dd pending, want %s, %dd", s.State, s.PendingDays, tt.wantState, tt.wantPending)
+			}
+			if tt.backport != nil && s.BackportNumber != 11 {
+				t.Errorf("expected backport #11, got #%d", s.BackportNumber)
+			}
+		})
+	}
+
+	report := &Report{Since: now.AddDate(0, 0, -90), Statuses: []Status{
+		r.status("konveyor", "operator", merged, "release-0.7", backportPR("closed", &github.Timestamp{Time: now}), false),
+		{Org: "konveyor", Repo: "kantra", Number: 3, Title: "a | b", Branch: "release-0.8", State: StateMissing, PendingDays: 2},
+		r.status("konveyor", "operator", merged, "release-0.8", nil, true),
+	}}
+	pending := report.Pending()
+	if len(pending) != 2 || pending[0].PendingDays != 5 || pending[1].Number != 3 {
+		t.Errorf("expected the longest pending backport first, got %+v", pending)
+	}
+	md := RenderMarkdown(report)
+	for _, want := range []string{
+		"## Pending (2)",
+		"| [konveyor/operator#10](https://github.com/konveyor/operator/pull/10) :bug: Fix crash | release-0.8 | conflict | 5d |  |",
+		"| [konveyor/kantra#3]() a \\| b | release-0.8 | missing | 2d |  |",
+		"| release-0.7 | merged | 2024-03-06 | [#11](https://github.com/konveyor/operator/pull/11) |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+}
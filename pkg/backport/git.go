@@ -0,0 +1,91 @@
+package backport
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ConflictError is returned when a cherry-pick conflicts
+type ConflictError struct {
+	Branch string
+	Files  []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("cherry-pick to %s conflicts in %s", e.Branch, strings.Join(e.Files, ", "))
+}
+
+// Git runs git in a clone of the repository
+type Git struct {
+	Dir    string
+	Remote string
+}
+
+// NewGit returns a git runner for the clone in dir, pushing to origin
+func NewGit(dir string) *Git {
+	return &Git{Dir: dir, Remote: "origin"}
+}
+
+// CherryPick cherry-picks the commit on a new branch created from the release
+// branch, recording the original commit. Merge commits are picked against
+// their first parent. It returns a *ConflictError when the commit does not
+// apply, leaving the clone clean.
+func (g *Git) CherryPick(sha, branch, name string) error {
+	return g.CherryPickCommits(sha, 1, branch, name)
+}
+
+// CherryPickCommits cherry-picks the commits ending at sha like CherryPick,
+// e.g. the commits a rebase merge put on the base branch
+func (g *Git) CherryPickCommits(sha string, commits int, branch, name string) error {
+	remoteBranch := g.Remote + "/" + branch
+	if _, err := g.run("fetch", g.Remote, fmt.Sprintf("+refs/heads/%s:refs/remotes/%s", branch, remoteBranch)); err != nil {
+		return err
+	}
+	if _, err := g.run("cat-file", "-e", sha+"^{commit}"); err != nil {
+		if _, err := g.run("fetch", g.Remote, sha); err != nil {
+			return err
+		}
+	}
+	if _, err := g.run("checkout", "-B", name, remoteBranch); err != nil {
+		return err
+	}
+
+	args := []string{"cherry-pick", "-x", "-s"}
+	parents, err := g.run("rev-list", "--parents", "-n", "1", sha)
+	if err != nil {
+		return err
+	}
+	if commits <= 1 && len(strings.Fields(parents)) > 2 {
+		args = append(args, "-m", "1")
+	}
+	if _, err := g.run(append(args, Revision(sha, commits))...); err != nil {
+		out, _ := g.run("diff", "--name-only", "--diff-filter=U")
+		// Leave the clone clean for the next branch
+		g.run("cherry-pick", "--abort")
+		if files := strings.Fields(out); len(files) > 0 {
+			return &ConflictError{Branch: branch, Files: files}
+		}
+		return err
+	}
+	return nil
+}
+
+// Push force pushes the branch, replacing a previous attempt
+func (g *Git) Push(name string) error {
+	_, err := g.run("push", "--force", g.Remote, name)
+	return err
+}
+
+func (g *Git) run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.Dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
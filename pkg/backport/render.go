@@ -0,0 +1,64 @@
+package backport
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konveyor/release-tools/pkg/action"
+)
+
+// Render renders the report in the format
+func Render(r *Report, format string) (string, error) {
+	switch format {
+	case action.FormatMarkdown, "md":
+		return RenderMarkdown(r), nil
+	case action.FormatJSON:
+		data, err := action.MarshalJSON(r)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal the backport report: %w", err)
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("unknown backport report format %q, expected %s or %s", format, action.FormatMarkdown, action.FormatJSON)
+}
+
+// RenderMarkdown renders the report as the pending backports, longest pending
+// first, followed by every backport by repo
+func RenderMarkdown(r *Report) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Backports of PRs merged since %s\n\n", r.Since.Format("2006-01-02"))
+	if len(r.Statuses) == 0 {
+		b.WriteString("No merged PR has a cherry-pick label.\n")
+		return b.String()
+	}
+
+	pending := r.Pending()
+	fmt.Fprintf(&b, "## Pending (%d)\n", len(pending))
+	if len(pending) == 0 {
+		b.WriteString("Every backport is merged.\n")
+	} else {
+		b.WriteString("| PR | Branch | State | Pending | Backport |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, s := range pending {
+			writeRow(&b, s, fmt.Sprintf("%dd", s.PendingDays))
+		}
+	}
+
+	b.WriteString("\n## All backports\n")
+	b.WriteString("| PR | Branch | State | Merged | Backport |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+	for _, s := range r.Statuses {
+		writeRow(&b, s, s.MergedAt.Format("2006-01-02"))
+	}
+	return b.String()
+}
+
+func writeRow(b *strings.Builder, s Status, when string) {
+	backport := ""
+	if s.BackportURL != "" {
+		backport = fmt.Sprintf("[#%d](%s)", s.BackportNumber, s.BackportURL)
+	}
+	fmt.Fprintf(b, "| [%s/%s#%d](%s) %s | %s | %s | %s | %s |\n",
+		s.Org, s.Repo, s.Number, s.URL, strings.ReplaceAll(s.Title, "|", "\\|"), s.Branch, s.State, when, backport)
+}
@@ -0,0 +1,244 @@
+package backport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/pr"
+	"github.com/sirupsen/logrus"
+)
+
+// States of a backport
+const (
+	// StateMissing is the state of a PR without backport PR
+	StateMissing = "missing"
+	StateOpen    = "open"
+	StateMerged  = "merged"
+	// StateClosed is the state of a backport PR closed without being merged
+	StateClosed = "closed"
+	// StateConflict is the state of a PR the cherry-pick bot failed to
+	// backport
+	StateConflict = "conflict"
+)
+
+// Status is the backport of a merged PR to a release branch its cherry-pick
+// label asks for
+type Status struct {
+	Org      string    `json:"org"`
+	Repo     string    `json:"repo"`
+	Number   int       `json:"number"`
+	Title    string    `json:"title"`
+	URL      string    `json:"url"`
+	Author   string    `json:"author"`
+	MergedAt time.Time `json:"merged_at"`
+	Branch   string    `json:"branch"`
+	State    string    `json:"state"`
+	// BackportNumber and BackportURL are empty when there is no backport PR
+	BackportNumber int    `json:"backport_number,omitempty"`
+	BackportURL    string `json:"backport_url,omitempty"`
+	// PendingDays is the number of days since the PR was merged while the
+	// backport is not merged
+	PendingDays int `json:"pending_days"`
+}
+
+// Pending returns true while the backport is not merged. A backport PR closed
+// without being merged is considered a decision not to backport.
+func (s Status) Pending() bool {
+	return s.State == StateMissing || s.State == StateOpen || s.State == StateConflict
+}
+
+// Report is the backport status of the PRs merged since a date
+type Report struct {
+	Since    time.Time `json:"since"`
+	Statuses []Status  `json:"statuses"`
+}
+
+// Pending returns the backports not merged yet, the longest pending first
+func (r *Report) Pending() []Status {
+	var pending []Status
+	for _, s := range r.Statuses {
+		if s.Pending() {
+			pending = append(pending, s)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].PendingDays > pending[j].PendingDays })
+	return pending
+}
+
+// Reporter reports the backport status of PRs
+type Reporter struct {
+	client *github.Client
+	now    func() time.Time
+}
+
+// NewReporter creates a new reporter with the given GitHub client
+func NewReporter(client *github.Client) *Reporter {
+	return &Reporter{client: client, now: time.Now}
+}
+
+// Report returns the backport status of the PRs with cherry-pick labels
+// merged in the repos since the date. Repos that fail are logged and skipped.
+func (r *Reporter) Report(ctx context.Context, repos []config.Repo, since time.Time) *Report {
+	report := &Report{Since: since, Statuses: []Status{}}
+	for _, repo := range repos {
+		statuses, err := r.repoStatuses(ctx, repo.Org, repo.Repo, since)
+		if err != nil {
+			logrus.WithError(err).Warnf("Failed to get the backports of %s/%s", repo.Org, repo.Repo)
+			continue
+		}
+		report.Statuses = append(report.Statuses, statuses...)
+	}
+	return report
+}
+
+func (r *Reporter) repoStatuses(ctx context.Context, org, repo string, since time.Time) ([]Status, error) {
+	pulls, err := r.mergedWithLabels(ctx, org, repo, since)
+	if err != nil {
+		return nil, err
+	}
+
+	// The backport PRs of each release branch, by the PR they backport
+	backports := make(map[string]map[int]*github.PullRequest)
+	var statuses []Status
+	for _, pull := range pulls {
+		labels := make(map[string]bool)
+		var names []string
+		for _, label := range pull.Labels {
+			labels[label.GetName()] = true
+			names = append(names, label.GetName())
+		}
+
+		for _, branch := range TargetBranches(names) {
+			if _, ok := backports[branch]; !ok {
+				backports[branch], err = r.backportPRs(ctx, org, repo, branch, since)
+				if err != nil {
+					return nil, err
+				}
+			}
+			statuses = append(statuses, r.status(org, repo, pull, branch, backports[branch][pull.GetNumber()], labels[FailedLabel(branch)]))
+		}
+	}
+	return statuses, nil
+}
+
+func (r *Reporter) status(org, repo string, pull *github.PullRequest, branch string, backport *github.PullRequest, failed bool) Status {
+	s := Status{
+		Org:      org,
+		Repo:     repo,
+		Number:   pull.GetNumber(),
+		Title:    pull.GetTitle(),
+		URL:      pull.GetHTMLURL(),
+		Author:   pull.GetUser().GetLogin(),
+		MergedAt: pull.GetMergedAt().Time,
+		Branch:   branch,
+	}
+	switch {
+	case backport == nil && failed:
+		s.State = StateConflict
+	case backport == nil:
+		s.State = StateMissing
+	case backport.MergedAt != nil:
+		s.State = StateMerged
+	case backport.GetState() == "open":
+		s.State = StateOpen
+	default:
+		s.State = StateClosed
+	}
+	if backport != nil {
+		s.BackportNumber = backport.GetNumber()
+		s.BackportURL = backport.GetHTMLURL()
+	}
+	if s.Pending() {
+		s.PendingDays = int(r.now().Sub(s.MergedAt).Hours() / 24)
+	}
+	return s
+}
+
+// mergedWithLabels returns the PRs merged since the date with a cherry-pick
+// label
+func (r *Reporter) mergedWithLabels(ctx context.Context, org, repo string, since time.Time) ([]*github.PullRequest, error) {
+	opts := &github.PullRequestListOptions{
+		State:       "closed",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	var merged []*github.PullRequest
+	for {
+		pulls, resp, err := r.client.PullRequests.List(ctx, org, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list closed PRs: %w", err)
+		}
+		for _, pull := range pulls {
+			// A PR merged since the date was also updated since then
+			if pull.GetUpdatedAt().Before(since) {
+				return merged, nil
+			}
+			if pull.MergedAt == nil || pull.GetMergedAt().Before(since) {
+				continue
+			}
+			var labels []string
+			for _, label := range pull.Labels {
+				labels = append(labels, label.GetName())
+			}
+			if len(TargetBranches(labels)) > 0 {
+				merged = append(merged, pull)
+			}
+		}
+		if resp.NextPage == 0 {
+			return merged, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// backportPRs returns the PRs to the release branch created since the date,
+// by the number of the PR they backport. A merged backport wins over an open
+// one, which wins over a closed one, the latest winning ties.
+func (r *Reporter) backportPRs(ctx context.Context, org, repo, branch string, since time.Time) (map[int]*github.PullRequest, error) {
+	opts := &github.PullRequestListOptions{
+		State:       "all",
+		Base:        branch,
+		Sort:        "created",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	backports := make(map[int]*github.PullRequest)
+	for {
+		pulls, resp, err := r.client.PullRequests.List(ctx, org, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list PRs to %s: %w", branch, err)
+		}
+		for _, pull := range pulls {
+			// Backports are opened once the PR is merged
+			if pull.GetCreatedAt().Before(since) {
+				return backports, nil
+			}
+			number, ok := pr.BackportOf(pull.GetHead().GetRef(), pull.GetBody())
+			if !ok {
+				continue
+			}
+			if existing, seen := backports[number]; !seen || rank(pull) > rank(existing) {
+				backports[number] = pull
+			}
+		}
+		if resp.NextPage == 0 {
+			return backports, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func rank(pull *github.PullRequest) int {
+	switch {
+	case pull.MergedAt != nil:
+		return 2
+	case pull.GetState() == "open":
+		return 1
+	}
+	return 0
+}
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/konveyor/release-tools/pkg/action"
 	"gopkg.in/yaml.v2"
@@ -19,9 +21,35 @@ func LoadConfig(path string) (*Configuration, error) {
 		action.ErrorCommand("Failed to unmarshal config")
 		return nil, err
 	}
+	for _, r := range c.Repos {
+		if err := r.TitleLint.Validate(); err != nil {
+			action.ErrorCommand("Invalid config")
+			return nil, fmt.Errorf("%s/%s: %w", r.Org, r.Repo, err)
+		}
+	}
 	return &c, nil
 }
 
+// Validate checks the warnings name known rules, a nil config is valid
+func (c *TitleLintConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, warning := range c.Warnings {
+		known := false
+		for _, rule := range LintRules {
+			if warning == rule {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown title lint rule %q in warnings, expected one of %s", warning, strings.Join(LintRules, ", "))
+		}
+	}
+	return nil
+}
+
 // LoadMaintainerConfig loads the maintainer configuration from the specified YAML file
 func LoadMaintainerConfig(path string) (*MaintainerConfig, error) {
 	data, err := os.ReadFile(path)
@@ -0,0 +1,73 @@
+package config
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+
+	"github.com/konveyor/release-tools/pkg/action"
+	"gopkg.in/yaml.v2"
+)
+
+//go:embed pr-types.yaml
+var defaultPRTypes []byte
+
+// DefaultPRTypes returns the PR types described in VERSIONING.md.
+func DefaultPRTypes() *PRTypeRegistry {
+	r, err := parsePRTypes(defaultPRTypes)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in PR types: %v", err))
+	}
+	return r
+}
+
+// LoadPRTypes loads a PR type registry from the specified YAML file
+func LoadPRTypes(path string) (*PRTypeRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		action.ErrorCommand("Failed reading PR types")
+		return nil, err
+	}
+
+	r, err := parsePRTypes(data)
+	if err != nil {
+		action.ErrorCommand("Failed to load PR types")
+		return nil, err
+	}
+	return r, nil
+}
+
+func parsePRTypes(data []byte) (*PRTypeRegistry, error) {
+	var r PRTypeRegistry
+	if err := yaml.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	switch r.Extends {
+	case "":
+	case ExtendsDefault:
+		r.Types = append(DefaultPRTypes().Types, r.Types...)
+	default:
+		return nil, fmt.Errorf("PR types can only extend %q, not %q", ExtendsDefault, r.Extends)
+	}
+	if len(r.Types) == 0 {
+		return nil, fmt.Errorf("no PR types defined")
+	}
+
+	seen := make(map[string]bool)
+	for i, t := range r.Types {
+		if t.Alias == "" || t.Type == "" {
+			return nil, fmt.Errorf("PR type #%d must have both an alias and a type", i+1)
+		}
+		if seen[t.Alias] {
+			return nil, fmt.Errorf("PR type alias %q defined more than once", t.Alias)
+		}
+		seen[t.Alias] = true
+
+		switch t.Impact {
+		case ImpactNone, ImpactPatch, ImpactMinor, ImpactMajor:
+		default:
+			return nil, fmt.Errorf("PR type %q has invalid impact %q", t.Alias, t.Impact)
+		}
+	}
+	return &r, nil
+}
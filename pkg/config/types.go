@@ -13,6 +13,64 @@ type Configuration struct {
 type Repo struct {
 	Org  string `json:"org"`
 	Repo string `json:"repo"`
+	// TitleLint enables PR title linting for the repo when set
+	TitleLint *TitleLintConfig `json:"title_lint,omitempty" yaml:"title_lint,omitempty"`
+	// Images are the container images built from the repo, e.g.
+	// quay.io/konveyor/tackle2-hub, tagged with the version of a release
+	Images []string `json:"images,omitempty" yaml:"images,omitempty"`
+	// Release opts the repo out of Konveyor releases when false, it is
+	// neither branched, tagged, verified nor pinned in the manifest
+	Release *bool `json:"release,omitempty" yaml:"release,omitempty"`
+}
+
+// Released returns true unless the repo opted out of Konveyor releases
+func (r Repo) Released() bool {
+	return r.Release == nil || *r.Release
+}
+
+// ReleaseRepos returns the repos that are part of a Konveyor release
+func (c *Configuration) ReleaseRepos() []Repo {
+	var repos []Repo
+	for _, r := range c.Repos {
+		if r.Released() {
+			repos = append(repos, r)
+		}
+	}
+	return repos
+}
+
+// TitleLintConfig holds the PR title style rules checked once the PR type
+// prefix is stripped. Zero values disable a rule.
+type TitleLintConfig struct {
+	MinLength             int      `json:"min_length" yaml:"min_length"`
+	MaxLength             int      `json:"max_length" yaml:"max_length"`
+	NoTrailingPunctuation bool     `json:"no_trailing_punctuation" yaml:"no_trailing_punctuation"`
+	NoLeadingLowercase    bool     `json:"no_leading_lowercase" yaml:"no_leading_lowercase"`
+	BannedWords           []string `json:"banned_words,omitempty" yaml:"banned_words,omitempty"`
+	NoTicketOnly          bool     `json:"no_ticket_only" yaml:"no_ticket_only"`
+	// Warnings lists the rules reported as warnings instead of errors, named
+	// after their key, e.g. "max_length"
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// The PR title style rules, named after their key in TitleLintConfig
+const (
+	LintMinLength             = "min_length"
+	LintMaxLength             = "max_length"
+	LintNoTrailingPunctuation = "no_trailing_punctuation"
+	LintNoLeadingLowercase    = "no_leading_lowercase"
+	LintBannedWords           = "banned_words"
+	LintNoTicketOnly          = "no_ticket_only"
+)
+
+// LintRules are the names of every PR title style rule
+var LintRules = []string{
+	LintMinLength,
+	LintMaxLength,
+	LintNoTrailingPunctuation,
+	LintNoLeadingLowercase,
+	LintBannedWords,
+	LintNoTicketOnly,
 }
 
 // Label holds declarative data about the label.
@@ -92,5 +150,44 @@ type ActionItemsConfig struct {
 	CheckExternalContributors    bool     `json:"check_external_contributors" yaml:"check_external_contributors"`
 	CheckPRsAwaitingAuthor       bool     `json:"check_prs_awaiting_author" yaml:"check_prs_awaiting_author"`
 	PRAwaitingAuthorResponseDays int      `json:"pr_awaiting_author_response_days" yaml:"pr_awaiting_author_response_days"`
+	CheckPendingBackports        bool     `json:"check_pending_backports" yaml:"check_pending_backports"`
+	BackportLookbackDays         int      `json:"backport_lookback_days" yaml:"backport_lookback_days"`
 	ExcludedLabels               []string `json:"excluded_labels,omitempty" yaml:"excluded_labels,omitempty"`
 }
+
+// PRTypeRegistry holds the PR types recognized in PR titles
+type PRTypeRegistry struct {
+	// Extends is "default" to add the types to the default ones, which come
+	// first
+	Extends string         `json:"extends,omitempty" yaml:"extends,omitempty"`
+	Types   []PRTypeConfig `json:"types" yaml:"types"`
+}
+
+// ExtendsDefault is the Extends of a registry adding types to the default ones
+const ExtendsDefault = "default"
+
+// PRTypeConfig maps a PR title prefix to a PR type
+type PRTypeConfig struct {
+	// Alias is the emoji alias used as the title prefix, e.g. ":sparkles:"
+	Alias string `json:"alias" yaml:"alias"`
+	// Emoji is the emoji character the alias renders as, e.g. "✨"
+	Emoji string `json:"emoji" yaml:"emoji"`
+	// Type is the PR type reported for the prefix, e.g. "feature"
+	Type string `json:"type" yaml:"type"`
+	// Description is a short human readable name, e.g. "Non-breaking feature"
+	Description string `json:"description" yaml:"description"`
+	// Section is the release note section, empty if left out of release notes
+	Section string `json:"section" yaml:"section"`
+	// Impact is the semantic version impact of the PR type
+	Impact SemverImpact `json:"impact" yaml:"impact"`
+}
+
+// SemverImpact is the part of the version a change bumps
+type SemverImpact string
+
+const (
+	ImpactNone  SemverImpact = "none"
+	ImpactPatch SemverImpact = "patch"
+	ImpactMinor SemverImpact = "minor"
+	ImpactMajor SemverImpact = "major"
+)
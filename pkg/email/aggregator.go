@@ -7,7 +7,6 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/konveyor/release-tools/pkg/action"
@@ -194,7 +193,7 @@ func AggregateRepoData(org, repo string, data *HistoricalData) (*RepoReport, err
 				// Extract new contributors (excluding bots)
 				for _, username := range repoData.NewContributorsList {
 					// Skip bot accounts
-					if strings.Contains(strings.ToLower(username), "[bot]") {
+					if goals.IsBotAccount(username) {
 						continue
 					}
 					report.NewContributors = append(report.NewContributors, Contributor{
@@ -0,0 +1,33 @@
+package goals
+
+import (
+	"context"
+	"time"
+
+	"github.com/konveyor/release-tools/pkg/backport"
+	"github.com/konveyor/release-tools/pkg/config"
+)
+
+// fetchPendingBackports finds PRs merged in the last days with a cherry-pick
+// label whose backport is not merged
+func (f *Fetcher) fetchPendingBackports(ctx context.Context, repo config.Repo, lookbackDays int) []PendingBackport {
+	since := time.Now().AddDate(0, 0, -lookbackDays)
+	report := backport.NewReporter(f.client).Report(ctx, []config.Repo{repo}, since)
+
+	pending := make([]PendingBackport, 0)
+	for _, s := range report.Pending() {
+		pending = append(pending, PendingBackport{
+			Org:         s.Org,
+			Repo:        s.Repo,
+			Number:      s.Number,
+			Title:       s.Title,
+			Author:      s.Author,
+			Branch:      s.Branch,
+			State:       s.State,
+			DaysPending: s.PendingDays,
+			URL:         s.URL,
+			BackportURL: s.BackportURL,
+		})
+	}
+	return pending
+}
@@ -0,0 +1,32 @@
+package goals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// IsBotAccount returns true for bot accounts (e.g., dependabot[bot])
+func IsBotAccount(login string) bool {
+	return strings.Contains(strings.ToLower(login), "[bot]")
+}
+
+// CountMergedPRs counts the PRs of the author merged in the repo, only those
+// merged before the given time unless it is zero. A count of zero means the
+// author is a first-time contributor.
+func (f *Fetcher) CountMergedPRs(ctx context.Context, org, repo, author string, before time.Time) (int, error) {
+	query := fmt.Sprintf("type:pr repo:%s/%s author:%s is:merged", org, repo, author)
+	if !before.IsZero() {
+		query += " merged:<" + before.UTC().Format(time.RFC3339)
+	}
+	searchResult, _, err := f.client.Search.Issues(ctx, query, &github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to search merged PRs of %s: %w", author, err)
+	}
+	return searchResult.GetTotal(), nil
+}
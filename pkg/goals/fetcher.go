@@ -3,7 +3,6 @@ package goals
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/google/go-github/v55/github"
@@ -419,6 +418,9 @@ func (f *Fetcher) FetchActionItems(ctx context.Context, repos []config.Repo, cfg
 	if cfg.CheckPRsAwaitingAuthor && cfg.PRAwaitingAuthorResponseDays <= 0 {
 		return nil, fmt.Errorf("pr_awaiting_author_response_days must be >= 1 (got %d)", cfg.PRAwaitingAuthorResponseDays)
 	}
+	if cfg.CheckPendingBackports && cfg.BackportLookbackDays <= 0 {
+		return nil, fmt.Errorf("backport_lookback_days must be >= 1 (got %d)", cfg.BackportLookbackDays)
+	}
 
 	items := &ActionItems{
 		UnrespondedIssues:        make([]UnrespondedIssue, 0),
@@ -427,6 +429,7 @@ func (f *Fetcher) FetchActionItems(ctx context.Context, repos []config.Repo, cfg
 		ApprovedPRsReadyToMerge:  make([]ApprovedPR, 0),
 		ExternalContributorPRs:   make([]ExternalContributorPR, 0),
 		PRsAwaitingAuthorResponse: make([]PRAwaitingAuthor, 0),
+		PendingBackports:         make([]PendingBackport, 0),
 		FetchedAt:                time.Now(),
 	}
 
@@ -492,11 +495,17 @@ func (f *Fetcher) FetchActionItems(ctx context.Context, repos []config.Repo, cfg
 			}
 		}
 
+		// Fetch backports not merged yet
+		if cfg.CheckPendingBackports {
+			items.PendingBackports = append(items.PendingBackports, f.fetchPendingBackports(ctx, repo, cfg.BackportLookbackDays)...)
+		}
+
 		items.TotalChecked++
 	}
 
 	items.TotalItems = len(items.UnrespondedIssues) + len(items.UnreviewedPRs) + len(items.FailingBranches) +
-		len(items.ApprovedPRsReadyToMerge) + len(items.ExternalContributorPRs) + len(items.PRsAwaitingAuthorResponse)
+		len(items.ApprovedPRsReadyToMerge) + len(items.ExternalContributorPRs) + len(items.PRsAwaitingAuthorResponse) +
+		len(items.PendingBackports)
 
 	logrus.WithFields(logrus.Fields{
 		"unresponded_issues":         len(items.UnrespondedIssues),
@@ -505,6 +514,7 @@ func (f *Fetcher) FetchActionItems(ctx context.Context, repos []config.Repo, cfg
 		"approved_prs_ready":         len(items.ApprovedPRsReadyToMerge),
 		"external_contributor_prs":   len(items.ExternalContributorPRs),
 		"prs_awaiting_author":        len(items.PRsAwaitingAuthorResponse),
+		"pending_backports":          len(items.PendingBackports),
 		"total_items":                items.TotalItems,
 	}).Info("Action items fetched successfully")
 
@@ -892,7 +902,7 @@ func (f *Fetcher) fetchExternalContributorPRs(ctx context.Context, org, repo str
 			}
 
 			// Skip bot accounts (e.g., dependabot[bot])
-			if strings.Contains(strings.ToLower(author), "[bot]") {
+			if IsBotAccount(author) {
 				continue
 			}
 
@@ -910,11 +920,7 @@ func (f *Fetcher) fetchExternalContributorPRs(ctx context.Context, org, repo str
 
 			// Check if this is their first PR to this repo
 			isFirstTime := false
-			authorQuery := fmt.Sprintf("type:pr repo:%s/%s author:%s is:merged", org, repo, author)
-			searchResult, _, err := f.client.Search.Issues(ctx, authorQuery, &github.SearchOptions{
-				ListOptions: github.ListOptions{PerPage: 1},
-			})
-			if err == nil && searchResult.GetTotal() == 0 {
+			if merged, err := f.CountMergedPRs(ctx, org, repo, author, time.Time{}); err == nil && merged == 0 {
 				isFirstTime = true
 			}
 
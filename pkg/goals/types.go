@@ -171,6 +171,7 @@ type ActionItems struct {
 	ApprovedPRsReadyToMerge  []ApprovedPR
 	ExternalContributorPRs   []ExternalContributorPR
 	PRsAwaitingAuthorResponse []PRAwaitingAuthor
+	PendingBackports         []PendingBackport
 
 	TotalItems  int
 	FetchedAt   time.Time
@@ -251,3 +252,17 @@ type PRAwaitingAuthor struct {
 	DaysSinceRequest int
 	URL              string
 }
+
+// PendingBackport represents a merged PR with a cherry-pick label whose backport is not merged
+type PendingBackport struct {
+	Org         string
+	Repo        string
+	Number      int
+	Title       string
+	Author      string
+	Branch      string
+	State       string // "missing", "open" or "conflict"
+	DaysPending int
+	URL         string
+	BackportURL string
+}
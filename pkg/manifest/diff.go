@@ -0,0 +1,110 @@
+package manifest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Change is a field of a component that differs between two manifests. A
+// component added or removed is a change of its "component" field.
+type Change struct {
+	Component string `json:"component"`
+	Field     string `json:"field"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+	// Compare is the GitHub comparison of the commits, when the commit changed
+	Compare string `json:"compare,omitempty"`
+}
+
+// Diff returns the changes from the old manifest to the next one, in the
+// order of the components of the next manifest followed by the removed ones
+func Diff(old, next *Manifest) []Change {
+	var changes []Change
+
+	olds := map[string]Component{}
+	for _, c := range old.Components {
+		olds[c.Name()] = c
+	}
+	seen := map[string]bool{}
+	for _, c := range next.Components {
+		seen[c.Name()] = true
+		o, ok := olds[c.Name()]
+		if !ok {
+			changes = append(changes, Change{Component: c.Name(), Field: "component", New: c.Tag})
+			continue
+		}
+		changes = append(changes, diffComponent(o, c)...)
+	}
+	for _, c := range old.Components {
+		if !seen[c.Name()] {
+			changes = append(changes, Change{Component: c.Name(), Field: "component", Old: c.Tag})
+		}
+	}
+	return changes
+}
+
+func diffComponent(old, next Component) []Change {
+	var changes []Change
+	add := func(field, o, n string) {
+		if o != n {
+			changes = append(changes, Change{Component: next.Name(), Field: field, Old: o, New: n})
+		}
+	}
+
+	add("tag", old.Tag, next.Tag)
+	add("commit", old.Commit, next.Commit)
+	if old.Commit != "" && next.Commit != "" && old.Commit != next.Commit {
+		changes[len(changes)-1].Compare = fmt.Sprintf("https://github.com/%s/compare/%s...%s", next.Name(), old.Commit, next.Commit)
+	}
+	add("module", moduleString(old.Module), moduleString(next.Module))
+
+	oldImages := map[string]string{}
+	for _, i := range old.Images {
+		oldImages[i.Name] = i.Digest
+	}
+	for _, i := range next.Images {
+		add("image "+i.Name, oldImages[i.Name], i.Digest)
+		delete(oldImages, i.Name)
+	}
+	for _, i := range old.Images {
+		if _, removed := oldImages[i.Name]; removed {
+			add("image "+i.Name, i.Digest, "")
+		}
+	}
+	return changes
+}
+
+func moduleString(m *Module) string {
+	if m == nil {
+		return ""
+	}
+	return m.Path + "@" + m.Version
+}
+
+// RenderDiff renders the changes as a markdown table
+func RenderDiff(old, next *Manifest, changes []Change) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Changes from %s to %s\n\n", old.Version, next.Version)
+	if len(changes) == 0 {
+		b.WriteString("No component changed.\n")
+		return b.String()
+	}
+	b.WriteString("| Component | Field | Old | New |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, c := range changes {
+		o, n := orNone(c.Old), orNone(c.New)
+		if c.Compare != "" {
+			n = fmt.Sprintf("[%s](%s)", n, c.Compare)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", c.Component, c.Field, o, n)
+	}
+	return b.String()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,107 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/registry"
+	"github.com/konveyor/release-tools/pkg/semver"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// Generator generates the manifest of a release from GitHub and the registry
+type Generator struct {
+	client   *github.Client
+	registry *registry.Client
+}
+
+// NewGenerator creates a new generator with the given GitHub and registry
+// clients
+func NewGenerator(client *github.Client, registry *registry.Client) *Generator {
+	return &Generator{client: client, registry: registry}
+}
+
+// Generate pins the tag of the version of every repo that is part of a
+// release. It fails when one of them is not tagged or an image tag is
+// missing, a manifest must be complete.
+func (g *Generator) Generate(ctx context.Context, repos []config.Repo, version semver.Version) (*Manifest, error) {
+	m := &Manifest{Version: version.String()}
+	for _, r := range repos {
+		if !r.Released() {
+			continue
+		}
+		c, err := g.component(ctx, r, version)
+		if err != nil {
+			return nil, fmt.Errorf("%s/%s: %w", r.Org, r.Repo, err)
+		}
+		m.Components = append(m.Components, *c)
+	}
+	return m, nil
+}
+
+func (g *Generator) component(ctx context.Context, r config.Repo, version semver.Version) (*Component, error) {
+	tag := version.String()
+	c := &Component{Org: r.Org, Repo: r.Repo, Tag: tag}
+
+	ref, _, err := g.client.Git.GetRef(ctx, r.Org, r.Repo, "tags/"+tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag %s: %w", tag, err)
+	}
+	c.Commit = ref.GetObject().GetSHA()
+	if ref.GetObject().GetType() == "tag" {
+		t, _, err := g.client.Git.GetTag(ctx, r.Org, r.Repo, c.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get annotated tag %s: %w", tag, err)
+		}
+		c.Commit = t.GetObject().GetSHA()
+	}
+
+	c.Module, err = g.module(ctx, r.Org, r.Repo, c.Commit, version)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, image := range r.Images {
+		digest, err := g.registry.Digest(ctx, image, tag)
+		if err != nil {
+			return nil, err
+		}
+		if digest == "" {
+			return nil, fmt.Errorf("%s:%s is missing", image, tag)
+		}
+		c.Images = append(c.Images, Image{Name: image, Digest: digest})
+	}
+	return c, nil
+}
+
+// module returns the Go module of the go.mod at the root of the repo, nil
+// when there is none
+func (g *Generator) module(ctx context.Context, org, repo, commit string, version semver.Version) (*Module, error) {
+	file, _, resp, err := g.client.Repositories.GetContents(ctx, org, repo, "go.mod", &github.RepositoryContentGetOptions{Ref: commit})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get go.mod: %w", err)
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode go.mod: %w", err)
+	}
+	path := modfile.ModulePath([]byte(content))
+	if path == "" {
+		return nil, fmt.Errorf("go.mod has no module path")
+	}
+
+	v := version.String()
+	// A v2+ tag of a module without a major version suffix is required as
+	// +incompatible
+	if _, pathMajor, _ := module.SplitPathVersion(path); pathMajor == "" && version.Major >= 2 {
+		v += "+incompatible"
+	}
+	return &Module{Path: path, Version: v}, nil
+}
@@ -0,0 +1,67 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/konveyor/release-tools/pkg/action"
+	"sigs.k8s.io/yaml"
+)
+
+// Manifest pins what shipped in a Konveyor release
+type Manifest struct {
+	Version    string      `json:"version"`
+	Components []Component `json:"components"`
+}
+
+// Component is a repo of a release
+type Component struct {
+	Org    string `json:"org"`
+	Repo   string `json:"repo"`
+	Tag    string `json:"tag"`
+	Commit string `json:"commit"`
+	// Module is the Go module of the repo, when it has a go.mod at its root
+	Module *Module `json:"module,omitempty"`
+	Images []Image `json:"images,omitempty"`
+}
+
+// Name is the org/repo of the component
+func (c Component) Name() string {
+	return c.Org + "/" + c.Repo
+}
+
+// Module is a Go module and the version to require it at
+type Module struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// Image is a container image, without a tag, and the digest of its tag
+type Image struct {
+	Name   string `json:"name"`
+	Digest string `json:"digest"`
+}
+
+// Load reads a YAML or JSON manifest
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var m Manifest
+	if err := yaml.UnmarshalStrict(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Marshal renders the manifest in the format
+func Marshal(m *Manifest, format string) ([]byte, error) {
+	switch format {
+	case action.FormatYAML, "yml":
+		return yaml.Marshal(m)
+	case action.FormatJSON:
+		return action.MarshalJSON(m)
+	}
+	return nil, fmt.Errorf("unknown manifest format %q, expected %s or %s", format, action.FormatYAML, action.FormatJSON)
+}
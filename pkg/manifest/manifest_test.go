@@ -0,0 +1,144 @@
+package manifest
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/registry"
+	"github.com/konveyor/release-tools/pkg/semver"
+	"sigs.k8s.io/yaml"
+)
+
+func TestGenerate(t *testing.T) {
+	const hub = "1111111111111111111111111111111111111111"
+	const ui = "2222222222222222222222222222222222222222"
+	const digest = "sha256:0123456789abcdef"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/konveyor/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/konveyor/tackle2-hub/git/ref/tags/v0.8.0":
+			fmt.Fprint(w, `{"ref": "refs/tags/v0.8.0", "object": {"type": "tag", "sha": "3333"}}`)
+		case "/repos/konveyor/tackle2-hub/git/tags/3333":
+			fmt.Fprintf(w, `{"tag": "v0.8.0", "object": {"type": "commit", "sha": %q}}`, hub)
+		case "/repos/konveyor/tackle2-hub/contents/go.mod":
+			if r.URL.Query().Get("ref") != hub {
+				http.NotFound(w, r)
+				return
+			}
+			content := base64.StdEncoding.EncodeToString([]byte("module github.com/konveyor/tackle2-hub\n\ngo 1.21\n"))
+			fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "content": %q}`, content)
+		case "/repos/konveyor/tackle2-ui/git/ref/tags/v0.8.0":
+			fmt.Fprintf(w, `{"ref": "refs/tags/v0.8.0", "object": {"type": "commit", "sha": %q}}`, ui)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	mux.HandleFunc("/v2/konveyor/tackle2-hub/manifests/v0.8.0", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Docker-Content-Digest", digest)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	generator := NewGenerator(client, registry.NewClient(registry.Options{
+		Host:      strings.TrimPrefix(server.URL, "http://"),
+		PlainHTTP: true,
+	}))
+	v, _ := semver.Parse("v0.8.0")
+
+	got, err := generator.Generate(context.Background(), []config.Repo{
+		{Org: "konveyor", Repo: "tackle2-hub", Images: []string{"quay.io/konveyor/tackle2-hub"}},
+		{Org: "konveyor", Repo: "tackle2-ui"},
+		// Not tagged, it is not part of the release
+		{Org: "konveyor", Repo: "konveyor.github.io", Release: github.Bool(false)},
+	}, v)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	want := &Manifest{Version: "v0.8.0", Components: []Component{
+		{
+			Org: "konveyor", Repo: "tackle2-hub", Tag: "v0.8.0", Commit: hub,
+			Module: &Module{Path: "github.com/konveyor/tackle2-hub", Version: "v0.8.0"},
+			Images: []Image{{Name: "quay.io/konveyor/tackle2-hub", Digest: digest}},
+		},
+		{Org: "konveyor", Repo: "tackle2-ui", Tag: "v0.8.0", Commit: ui},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Generate() = %+v, want %+v", got, want)
+	}
+
+	// An image that was not pushed fails the manifest
+	_, err = generator.Generate(context.Background(), []config.Repo{
+		{Org: "konveyor", Repo: "tackle2-ui", Images: []string{"quay.io/konveyor/tackle2-ui"}},
+	}, v)
+	if err == nil {
+		t.Error("Generate() with a missing image, want an error")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := &Manifest{Version: "v0.7.0", Components: []Component{
+		{
+			Org: "konveyor", Repo: "tackle2-hub", Tag: "v0.7.0", Commit: "aaa",
+			Module: &Module{Path: "github.com/konveyor/tackle2-hub", Version: "v0.7.0"},
+			Images: []Image{{Name: "quay.io/konveyor/tackle2-hub", Digest: "sha256:1"}},
+		},
+		{Org: "konveyor", Repo: "removed", Tag: "v0.7.0", Commit: "ccc"},
+	}}
+	next := &Manifest{Version: "v0.8.0", Components: []Component{
+		{
+			Org: "konveyor", Repo: "tackle2-hub", Tag: "v0.8.0", Commit: "bbb",
+			Module: &Module{Path: "github.com/konveyor/tackle2-hub", Version: "v0.8.0"},
+			Images: []Image{{Name: "quay.io/konveyor/tackle2-hub", Digest: "sha256:2"}},
+		},
+		{Org: "konveyor", Repo: "added", Tag: "v0.8.0", Commit: "ddd"},
+	}}
+
+	// The manifests go through YAML, as when diffing files
+	for _, m := range []*Manifest{old, next} {
+		data, err := Marshal(m, action.FormatYAML)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(t.TempDir(), "manifest.yaml")
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		*m = *loaded
+	}
+
+	want := []Change{
+		{Component: "konveyor/tackle2-hub", Field: "tag", Old: "v0.7.0", New: "v0.8.0"},
+		{Component: "konveyor/tackle2-hub", Field: "commit", Old: "aaa", New: "bbb", Compare: "https://github.com/konveyor/tackle2-hub/compare/aaa...bbb"},
+		{Component: "konveyor/tackle2-hub", Field: "module", Old: "github.com/konveyor/tackle2-hub@v0.7.0", New: "github.com/konveyor/tackle2-hub@v0.8.0"},
+		{Component: "konveyor/tackle2-hub", Field: "image quay.io/konveyor/tackle2-hub", Old: "sha256:1", New: "sha256:2"},
+		{Component: "konveyor/added", Field: "component", New: "v0.8.0"},
+		{Component: "konveyor/removed", Field: "component", Old: "v0.7.0"},
+	}
+	got := Diff(old, next)
+	if !reflect.DeepEqual(got, want) {
+		y, _ := yaml.Marshal(got)
+		t.Errorf("Diff() =\n%s", y)
+	}
+	if len(Diff(next, next)) != 0 {
+		t.Error("Diff() of a manifest with itself, want no change")
+	}
+}
@@ -0,0 +1,112 @@
+package notes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/pr"
+	"github.com/sirupsen/logrus"
+)
+
+// originalPRs returns the PRs the backport PRs merged into release-X.Y
+// branches cherry-pick, by the number of the backport PR
+func (g *Generator) originalPRs(ctx context.Context, org, repo string, pulls []*github.PullRequest) map[int]*github.PullRequest {
+	originals := make(map[int]*github.PullRequest)
+	for _, pull := range pulls {
+		if !pr.IsReleaseBranch(pull.GetBase().GetRef()) {
+			continue
+		}
+		original, err := g.originalPR(ctx, org, repo, pull)
+		if err != nil {
+			logrus.WithError(err).Warnf("Failed to resolve the PR backported by #%d", pull.GetNumber())
+			continue
+		}
+		if original != nil {
+			originals[pull.GetNumber()] = original
+		}
+	}
+	return originals
+}
+
+// originalPR returns the PR the backport PR cherry-picks, from its branch, its
+// description or the cherry-pick trailers of its commits. It returns nil when
+// the PR is not a backport.
+func (g *Generator) originalPR(ctx context.Context, org, repo string, backport *github.PullRequest) (*github.PullRequest, error) {
+	if number, ok := pr.BackportOf(backport.GetHead().GetRef(), backport.GetBody()); ok && number != backport.GetNumber() {
+		original, _, err := g.client.PullRequests.Get(ctx, org, repo, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PR #%d: %w", number, err)
+		}
+		return original, nil
+	}
+
+	commits, _, err := g.client.PullRequests.ListCommits(ctx, org, repo, backport.GetNumber(), &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+	for _, c := range commits {
+		for _, sha := range pr.CherryPickedFrom(c.GetCommit().GetMessage()) {
+			prs, _, err := g.client.PullRequests.ListPullRequestsWithCommit(ctx, org, repo, sha, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list PRs of commit %s: %w", sha, err)
+			}
+			for _, original := range prs {
+				if original.MergedAt != nil && original.GetNumber() != backport.GetNumber() {
+					return original, nil
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// replaceBackports replaces the backport PRs by the PRs they backport, so the
+// original PR and author are credited and each change is listed once. The
+// original keeps the merge time of its first backport in the range. It
+// returns the backport of each original PR.
+func replaceBackports(pulls []*github.PullRequest, originals map[int]*github.PullRequest) ([]*github.PullRequest, map[int]*github.PullRequest) {
+	var resolved []*github.PullRequest
+	backports := make(map[int]*github.PullRequest)
+	seen := make(map[int]bool)
+	for _, pull := range pulls {
+		original, ok := originals[pull.GetNumber()]
+		if !ok {
+			if !seen[pull.GetNumber()] {
+				seen[pull.GetNumber()] = true
+				resolved = append(resolved, pull)
+			}
+			continue
+		}
+
+		if seen[original.GetNumber()] {
+			if _, ok := backports[original.GetNumber()]; !ok {
+				backports[original.GetNumber()] = pull
+			}
+			continue
+		}
+		seen[original.GetNumber()] = true
+		backports[original.GetNumber()] = pull
+
+		credited := *original
+		credited.MergedAt = pull.MergedAt
+		resolved = append(resolved, &credited)
+	}
+	return resolved, backports
+}
+
+// linkBackports links the entries of original PRs to their backport
+func linkBackports(n *Notes, backports map[int]*github.PullRequest) {
+	link := func(entries []Entry) {
+		for i := range entries {
+			if backport, ok := backports[entries[i].Number]; ok {
+				entries[i].BackportNumber = backport.GetNumber()
+				entries[i].BackportURL = backport.GetHTMLURL()
+			}
+		}
+	}
+	for _, s := range n.Sections {
+		link(s.Entries)
+	}
+	link(n.Unclassified)
+}
@@ -0,0 +1,90 @@
+package notes
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Formatter renders release notes
+type Formatter interface {
+	// Format renders the release notes of a repository
+	Format(n *Notes) (string, error)
+	// FormatRelease renders the combined release notes of a Konveyor release
+	FormatRelease(r *Release) (string, error)
+}
+
+// Formats supported by NewFormatter
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatHTML     = "html"
+)
+
+// NewFormatter returns the formatter of the format
+func NewFormatter(format string) (Formatter, error) {
+	switch format {
+	case FormatMarkdown, "md":
+		return MarkdownFormatter{}, nil
+	case FormatJSON:
+		return JSONFormatter{}, nil
+	case FormatYAML, "yml":
+		return YAMLFormatter{}, nil
+	case FormatHTML:
+		return HTMLFormatter{}, nil
+	}
+	return nil, fmt.Errorf("unknown release notes format %q, expected one of %s, %s, %s or %s",
+		format, FormatMarkdown, FormatJSON, FormatYAML, FormatHTML)
+}
+
+// MarkdownFormatter renders release notes as the markdown body of a GitHub
+// release
+type MarkdownFormatter struct{}
+
+func (MarkdownFormatter) Format(n *Notes) (string, error) {
+	return RenderMarkdown(n), nil
+}
+
+func (MarkdownFormatter) FormatRelease(r *Release) (string, error) {
+	return RenderReleaseMarkdown(r), nil
+}
+
+// JSONFormatter renders release notes as JSON
+type JSONFormatter struct{}
+
+func (JSONFormatter) Format(n *Notes) (string, error) {
+	return marshalJSON(n)
+}
+
+func (JSONFormatter) FormatRelease(r *Release) (string, error) {
+	return marshalJSON(r)
+}
+
+func marshalJSON(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal release notes: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// YAMLFormatter renders release notes as YAML, with the same fields as JSON
+type YAMLFormatter struct{}
+
+func (YAMLFormatter) Format(n *Notes) (string, error) {
+	return marshalYAML(n)
+}
+
+func (YAMLFormatter) FormatRelease(r *Release) (string, error) {
+	return marshalYAML(r)
+}
+
+func marshalYAML(v interface{}) (string, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal release notes: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,329 @@
+package notes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/goals"
+	"github.com/konveyor/release-tools/pkg/pr"
+	"github.com/sirupsen/logrus"
+)
+
+// searchInterval spaces the searches of the new contributor checks, the
+// Search API allows 30 requests per minute
+const searchInterval = 2 * time.Second
+
+// Generator generates release notes from the PRs merged in a repository
+type Generator struct {
+	client   *github.Client
+	registry *pr.Registry
+
+	searchInterval time.Duration
+	lastSearch     time.Time
+}
+
+// NewGenerator creates a new generator, a nil registry uses the default PR
+// types
+func NewGenerator(client *github.Client, registry *pr.Registry) *Generator {
+	return &Generator{
+		client:         client,
+		registry:       registry,
+		searchInterval: searchInterval,
+	}
+}
+
+// Generate returns the release notes for the PRs merged after from up to and
+// including to. An empty from includes the whole history of to.
+func (g *Generator) Generate(ctx context.Context, org, repo, from, to string) (*Notes, error) {
+	pulls, err := g.MergedPRs(ctx, org, repo, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Backports are credited to the PRs they cherry-pick
+	pulls, backports := replaceBackports(pulls, g.originalPRs(ctx, org, repo, pulls))
+
+	n := Build(pulls, g.registry)
+	linkBackports(n, backports)
+	n.Org, n.Repo, n.From, n.To = org, repo, from, to
+	n.Contributors = Contributors(pulls)
+	n.NewContributors, n.UncheckedContributors, err = g.newContributors(ctx, org, repo, n.Contributors)
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// Contributors returns the authors of the PRs sorted by login, bots are left
+// out
+func Contributors(pulls []*github.PullRequest) []Contributor {
+	byLogin := make(map[string]*Contributor)
+	for _, pull := range pulls {
+		login := pull.GetUser().GetLogin()
+		if login == "" || goals.IsBotAccount(login) {
+			continue
+		}
+		c, ok := byLogin[login]
+		if !ok {
+			c = &Contributor{Login: login}
+			byLogin[login] = c
+		}
+		c.PRs++
+		if mergedAt := pull.GetMergedAt().Time; c.FirstPR == "" || mergedAt.Before(c.firstMergedAt) {
+			c.FirstPR, c.firstMergedAt = pull.GetHTMLURL(), mergedAt
+		}
+	}
+
+	contributors := make([]Contributor, 0, len(byLogin))
+	for _, c := range byLogin {
+		contributors = append(contributors, *c)
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		return strings.ToLower(contributors[i].Login) < strings.ToLower(contributors[j].Login)
+	})
+	return contributors
+}
+
+// newContributors returns the contributors without PRs merged before their
+// first PR of the range, and the logins of those that could not be checked
+func (g *Generator) newContributors(ctx context.Context, org, repo string, contributors []Contributor) ([]Contributor, []string, error) {
+	fetcher := goals.NewFetcher(g.client, nil)
+	var newContributors []Contributor
+	var unchecked []string
+	for _, c := range contributors {
+		merged, err := g.countMergedPRs(ctx, fetcher, org, repo, c)
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		if err != nil {
+			logrus.WithError(err).Warnf("Failed to check if %s is a new contributor to %s/%s", c.Login, org, repo)
+			unchecked = append(unchecked, c.Login)
+			continue
+		}
+		if merged == 0 {
+			newContributors = append(newContributors, c)
+		}
+	}
+	return newContributors, unchecked, nil
+}
+
+// countMergedPRs counts the PRs of the contributor merged before their first
+// PR of the range. Searches are spaced by the search interval, a search hitting
+// the rate limit is retried once the limit resets.
+func (g *Generator) countMergedPRs(ctx context.Context, fetcher *goals.Fetcher, org, repo string, c Contributor) (int, error) {
+	for retried := false; ; retried = true {
+		if err := sleep(ctx, time.Until(g.lastSearch.Add(g.searchInterval))); err != nil {
+			return 0, err
+		}
+		g.lastSearch = time.Now()
+		merged, err := fetcher.CountMergedPRs(ctx, org, repo, c.Login, c.firstMergedAt)
+		wait, limited := rateLimitWait(err)
+		if !limited || retried {
+			return merged, err
+		}
+		logrus.WithField("wait_time", wait).Info("Waiting for the search rate limit to reset")
+		if err := sleep(ctx, wait); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// rateLimitWait returns how long to wait before retrying a request that hit a
+// rate limit, false if the error is not about a rate limit
+func rateLimitWait(err error) (time.Duration, bool) {
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		return time.Until(rateErr.Rate.Reset.Time) + time.Second, true
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		return abuseErr.GetRetryAfter(), true
+	}
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// MergedPRs returns the PRs merged after from up to and including to, each PR
+// once. An empty from includes the whole history of to.
+func (g *Generator) MergedPRs(ctx context.Context, org, repo, from, to string) ([]*github.PullRequest, error) {
+	commits, err := g.listCommits(ctx, org, repo, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// PRs updated before from was committed were merged before it
+	var since time.Time
+	if from != "" {
+		c, _, err := g.client.Repositories.GetCommit(ctx, org, repo, from, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit %s: %w", from, err)
+		}
+		since = c.GetCommit().GetCommitter().GetDate().Time
+	}
+
+	pulls, err := g.mergedPRs(ctx, org, repo, commits, since)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"repo":    fmt.Sprintf("%s/%s", org, repo),
+		"from":    from,
+		"to":      to,
+		"commits": len(commits),
+		"prs":     len(pulls),
+	}).Info("Collected merged PRs")
+	return pulls, nil
+}
+
+// Build classifies the PRs by their title, PRs that are left out of release
+// notes (e.g. :ghost:) are dropped
+func Build(pulls []*github.PullRequest, registry *pr.Registry) *Notes {
+	n := &Notes{}
+	sections := make(map[pr.PRType]int)
+	for _, t := range registry.Types() {
+		if t.Section == "" {
+			continue
+		}
+		sections[pr.PRType(t.Type)] = len(n.Sections)
+		n.Sections = append(n.Sections, Section{
+			Type:  pr.PRType(t.Type),
+			Title: t.Section,
+			Alias: t.Alias,
+			Emoji: t.Emoji,
+		})
+	}
+
+	sort.SliceStable(pulls, func(i, j int) bool {
+		return pulls[i].GetMergedAt().Before(pulls[j].GetMergedAt().Time)
+	})
+
+	for _, pull := range pulls {
+		entry := newEntry(pull)
+		title, err := registry.ParseTitle(pull.GetTitle(), false)
+		if err != nil {
+			entry.Type = pr.UnknownPR
+			entry.Title = pull.GetTitle()
+			n.Unclassified = append(n.Unclassified, entry)
+			continue
+		}
+		entry.Type, entry.Scope, entry.Title = title.Type, title.Scope, title.Title
+
+		i, ok := sections[title.Type]
+		if !ok {
+			continue
+		}
+		n.Sections[i].Entries = append(n.Sections[i].Entries, entry)
+	}
+	return n
+}
+
+func newEntry(pull *github.PullRequest) Entry {
+	entry := Entry{
+		Number:   pull.GetNumber(),
+		URL:      pull.GetHTMLURL(),
+		Author:   pull.GetUser().GetLogin(),
+		MergedAt: pull.GetMergedAt().Time,
+	}
+	if note, ok := pr.NoteFromBody(pull.GetBody()); ok && !pr.IsNoneNote(note) {
+		entry.Note = note
+	}
+	base := pull.GetBase().GetRepo()
+	entry.Issues = pr.LinkedIssues(pull.GetBody(), base.GetOwner().GetLogin(), base.GetName())
+	for _, label := range pull.Labels {
+		entry.Labels = append(entry.Labels, label.GetName())
+	}
+	return entry
+}
+
+// listCommits returns the SHAs of the commits in the range
+func (g *Generator) listCommits(ctx context.Context, org, repo, from, to string) ([]string, error) {
+	var shas []string
+	opts := &github.ListOptions{PerPage: 100}
+
+	if from == "" {
+		commitOpts := &github.CommitsListOptions{SHA: to, ListOptions: *opts}
+		for {
+			commits, resp, err := g.client.Repositories.ListCommits(ctx, org, repo, commitOpts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list commits of %s: %w", to, err)
+			}
+			for _, c := range commits {
+				shas = append(shas, c.GetSHA())
+			}
+			if resp.NextPage == 0 {
+				break
+			}
+			commitOpts.Page = resp.NextPage
+		}
+		return shas, nil
+	}
+
+	for {
+		comparison, resp, err := g.client.Repositories.CompareCommits(ctx, org, repo, from, to, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare %s...%s: %w", from, to, err)
+		}
+		for _, c := range comparison.Commits {
+			shas = append(shas, c.GetSHA())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return shas, nil
+}
+
+// mergedPRs returns the merged PRs whose merge commit is one of the commits.
+// Closed PRs are listed from the most recently updated, down to those updated
+// before since, instead of looking up the PRs of every commit.
+func (g *Generator) mergedPRs(ctx context.Context, org, repo string, shas []string, since time.Time) ([]*github.PullRequest, error) {
+	inRange := make(map[string]bool, len(shas))
+	for _, sha := range shas {
+		inRange[sha] = true
+	}
+
+	var pulls []*github.PullRequest
+	opts := &github.PullRequestListOptions{
+		State:       "closed",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		prs, resp, err := g.client.PullRequests.List(ctx, org, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list closed PRs: %w", err)
+		}
+		for _, pull := range prs {
+			if !since.IsZero() && pull.GetUpdatedAt().Before(since) {
+				return pulls, nil
+			}
+			if pull.MergedAt != nil && inRange[pull.GetMergeCommitSHA()] {
+				pulls = append(pulls, pull)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return pulls, nil
+}
@@ -0,0 +1,58 @@
+package notes
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TemplateDir is the directory holding the templates/release-notes HTML
+// templates, relative to the working directory
+var TemplateDir = "templates"
+
+// HTMLFormatter renders release notes as an HTML fragment, e.g. to embed in
+// konveyor.github.io
+type HTMLFormatter struct{}
+
+func (HTMLFormatter) Format(n *Notes) (string, error) {
+	return renderHTML("notes.html", n)
+}
+
+func (HTMLFormatter) FormatRelease(r *Release) (string, error) {
+	return renderHTML("release.html", r)
+}
+
+// renderHTML renders the template with the entry template shared by all
+// release notes templates
+func renderHTML(name string, data interface{}) (string, error) {
+	funcMap := template.FuncMap{
+		"changelogURL": changelogURL,
+		"componentChangelogURL": func(c Component) string {
+			return changelogURL(&Notes{Org: c.Org, Repo: c.Repo, From: c.Previous, To: c.Version})
+		},
+		"lines": func(s string) []string {
+			return strings.Split(s, "\n")
+		},
+	}
+
+	tmpl := template.New(name).Funcs(funcMap)
+	for _, file := range []string{"entry.html", name} {
+		tmplContent, err := os.ReadFile(filepath.Join(TemplateDir, "release-notes", file))
+		if err != nil {
+			return "", fmt.Errorf("failed to read HTML template: %w", err)
+		}
+		if _, err := tmpl.Parse(string(tmplContent)); err != nil {
+			return "", fmt.Errorf("failed to parse HTML template: %w", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute HTML template: %w", err)
+	}
+
+	return buf.String(), nil
+}
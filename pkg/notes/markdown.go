@@ -0,0 +1,157 @@
+package notes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderMarkdown renders the release notes as the markdown body of a GitHub
+// release
+func RenderMarkdown(n *Notes) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "**Full Changelog**: %s\n\n", changelogURL(n))
+
+	for _, s := range n.Sections {
+		if len(s.Entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "## %s %s\n", s.Alias, s.Title)
+		for _, e := range s.Entries {
+			writeEntry(&b, e)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(n.Unclassified) > 0 {
+		b.WriteString("## :question: Unclassified\n")
+		b.WriteString("These PRs have no PR type prefix in their title.\n\n")
+		for _, e := range n.Unclassified {
+			writeEntry(&b, e)
+		}
+		b.WriteString("\n")
+	}
+
+	writeContributors(&b, n.NewContributors, n.UncheckedContributors, n.Contributors)
+
+	return b.String()
+}
+
+func writeEntry(b *strings.Builder, e Entry) {
+	b.WriteString("* ")
+	if e.Scope != "" {
+		fmt.Fprintf(b, "[%s] ", e.Scope)
+	}
+	b.WriteString(e.Title)
+	if e.Author != "" {
+		fmt.Fprintf(b, " by @%s", e.Author)
+	}
+	fmt.Fprintf(b, " in %s", e.URL)
+	if e.BackportURL != "" {
+		fmt.Fprintf(b, " (backport %s)", e.BackportURL)
+	}
+	b.WriteString("\n")
+
+	// The release note is indented to stay part of the list item
+	if e.Note != "" {
+		b.WriteString("\n")
+		for _, line := range strings.Split(e.Note, "\n") {
+			if line == "" {
+				b.WriteString("\n")
+				continue
+			}
+			fmt.Fprintf(b, "  %s\n", line)
+		}
+		b.WriteString("\n")
+	}
+}
+
+// changelogURL links the commits of the range on GitHub
+func changelogURL(n *Notes) string {
+	if n.From == "" {
+		return fmt.Sprintf("https://github.com/%s/%s/commits/%s", n.Org, n.Repo, n.To)
+	}
+	return fmt.Sprintf("https://github.com/%s/%s/compare/%s...%s", n.Org, n.Repo, n.From, n.To)
+}
+
+// RenderReleaseMarkdown renders the combined release notes of a Konveyor
+// release
+func RenderReleaseMarkdown(r *Release) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Konveyor %s\n\n", r.Version)
+
+	b.WriteString("## Components\n\n")
+	b.WriteString("| Component | Version | Previous | Changes |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, c := range r.Components {
+		previous := c.Previous
+		if previous == "" {
+			previous = "-"
+		}
+		n := &Notes{Org: c.Org, Repo: c.Repo, From: c.Previous, To: c.Version}
+		fmt.Fprintf(&b, "| [%s](https://github.com/%s/%s) | [%s](https://github.com/%s/%s/releases/tag/%s) | %s | [changes](%s) |\n",
+			c.Repo, c.Org, c.Repo, c.Version, c.Org, c.Repo, c.Version, previous, changelogURL(n))
+	}
+	b.WriteString("\n")
+
+	for _, s := range r.Sections {
+		if len(s.Components) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "## %s %s\n\n", s.Alias, s.Title)
+		writeComponents(&b, s.Components)
+	}
+
+	if len(r.Unclassified) > 0 {
+		b.WriteString("## :question: Unclassified\n")
+		b.WriteString("These PRs have no PR type prefix in their title.\n\n")
+		writeComponents(&b, r.Unclassified)
+	}
+
+	writeContributors(&b, r.NewContributors, r.UncheckedContributors, r.Contributors)
+
+	return b.String()
+}
+
+func writeContributors(b *strings.Builder, newContributors []Contributor, unchecked []string, contributors []Contributor) {
+	if len(newContributors) > 0 || len(unchecked) > 0 {
+		b.WriteString("## New Contributors\n")
+		for _, c := range newContributors {
+			fmt.Fprintf(b, "* @%s made their first contribution in %s\n", c.Login, c.FirstPR)
+		}
+		if len(unchecked) > 0 {
+			b.WriteString("\n:warning: This list may be incomplete, it could not be checked if")
+			for i, login := range unchecked {
+				if i > 0 {
+					b.WriteString(",")
+				}
+				fmt.Fprintf(b, " @%s", login)
+			}
+			b.WriteString(" made their first contribution.\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(contributors) > 0 {
+		b.WriteString("## Contributors\n")
+		b.WriteString("Thank you to everyone who contributed to this release:")
+		for i, c := range contributors {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			fmt.Fprintf(b, " @%s", c.Login)
+		}
+		b.WriteString("\n")
+	}
+}
+
+func writeComponents(b *strings.Builder, components []ComponentEntries) {
+	for _, c := range components {
+		fmt.Fprintf(b, "### [%s](https://github.com/%s/%s)\n", c.Repo, c.Org, c.Repo)
+		for _, e := range c.Entries {
+			writeEntry(b, e)
+		}
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,357 @@
+package notes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/pr"
+)
+
+func pull(number int, title, body string) *github.PullRequest {
+	return &github.PullRequest{
+		Number:   github.Int(number),
+		Title:    github.String(title),
+		Body:     github.String(body),
+		HTMLURL:  github.String(fmt.Sprintf("https://github.com/konveyor/tackle2-hub/pull/%d", number)),
+		User:     &github.User{Login: github.String("jane")},
+		MergedAt: &github.Timestamp{Time: time.Date(2024, 1, 1, 0, 0, number, 0, time.UTC)},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	pulls := []*github.PullRequest{
+		pull(3, ":bug: Fix the table", ""),
+		pull(1, ":sparkles: [ui] Add the report", "```release-note\nThe report is shown in the UI.\n```"),
+		pull(2, ":ghost: Bump deps", ""),
+		pull(4, "Update README", ""),
+		pull(5, ":warning: Drop the v1 API", "```release-note\nNONE\n```"),
+	}
+
+	n := Build(pulls, nil)
+	n.Org, n.Repo, n.From, n.To = "konveyor", "tackle2-hub", "v0.3.0", "v0.4.0"
+
+	expected := map[pr.PRType][]int{
+		pr.BreakingPR: {5},
+		pr.FeaturePR:  {1},
+		pr.BugFixPR:   {3},
+	}
+	for _, s := range n.Sections {
+		var numbers []int
+		for _, e := range s.Entries {
+			numbers = append(numbers, e.Number)
+		}
+		if fmt.Sprint(numbers) != fmt.Sprint(expected[s.Type]) {
+			t.Errorf("Expected %v in section %s but got %v", expected[s.Type], s.Title, numbers)
+		}
+	}
+	if len(n.Unclassified) != 1 || n.Unclassified[0].Number != 4 {
+		t.Errorf("Expected #4 to be unclassified but got %v", n.Unclassified)
+	}
+
+	md := RenderMarkdown(n)
+	for _, want := range []string{
+		"**Full Changelog**: https://github.com/konveyor/tackle2-hub/compare/v0.3.0...v0.4.0",
+		"## :warning: Breaking Changes\n* Drop the v1 API by @jane in https://github.com/konveyor/tackle2-hub/pull/5\n",
+		"## :sparkles: Features\n* [ui] Add the report by @jane in https://github.com/konveyor/tackle2-hub/pull/1\n\n  The report is shown in the UI.\n",
+		"## :question: Unclassified\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "Bump deps") || strings.Contains(md, "## :book: Docs") {
+		t.Errorf("Expected :ghost: PRs and empty sections to be left out, got:\n%s", md)
+	}
+}
+
+func TestContributors(t *testing.T) {
+	p1 := pull(1, ":bug: Fix the table", "")
+	p2 := pull(2, ":bug: Fix the form", "")
+	p3 := pull(3, ":seedling: Bump deps", "")
+	p3.User = &github.User{Login: github.String("dependabot[bot]")}
+	p4 := pull(4, ":ghost: Tidy up", "")
+	p4.User = &github.User{Login: github.String("Alice")}
+
+	contributors := Contributors([]*github.PullRequest{p2, p3, p1, p4})
+	if len(contributors) != 2 {
+		t.Fatalf("Expected 2 contributors but got %+v", contributors)
+	}
+	if contributors[0].Login != "Alice" || contributors[1].Login != "jane" {
+		t.Errorf("Expected contributors sorted by login but got %+v", contributors)
+	}
+	if contributors[1].PRs != 2 || contributors[1].FirstPR != p1.GetHTMLURL() {
+		t.Errorf("Expected jane to have 2 PRs starting with #1 but got %+v", contributors[1])
+	}
+
+	n := &Notes{Org: "konveyor", Repo: "tackle2-hub", To: "v0.4.0", Contributors: contributors, NewContributors: contributors[:1]}
+	md := RenderMarkdown(n)
+	for _, want := range []string{
+		"## New Contributors\n* @Alice made their first contribution in https://github.com/konveyor/tackle2-hub/pull/4\n",
+		"## Contributors\nThank you to everyone who contributed to this release: @Alice, @jane\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+}
+
+func TestNewRelease(t *testing.T) {
+	hub := Build([]*github.PullRequest{
+		pull(1, ":sparkles: Add the report", ""),
+		pull(2, ":bug: Fix the table", ""),
+	}, nil)
+	hub.Org, hub.Repo, hub.From, hub.To = "konveyor", "tackle2-hub", "v0.7.2", "v0.8.0"
+
+	ui := Build([]*github.PullRequest{
+		pull(3, ":sparkles: Show the report", ""),
+		pull(4, "Update README", ""),
+	}, nil)
+	ui.Org, ui.Repo, ui.From, ui.To = "konveyor", "tackle2-ui", "", "v0.8.0"
+
+	// jane is new to tackle2-ui but already contributed to tackle2-hub
+	jane, bob, alice := Contributor{Login: "jane"}, Contributor{Login: "bob"}, Contributor{Login: "alice"}
+	hub.Contributors, hub.NewContributors = []Contributor{bob, jane}, []Contributor{bob}
+	ui.Contributors, ui.NewContributors = []Contributor{alice, jane}, []Contributor{alice, jane}
+
+	// carol could not be checked in tackle2-ui, jane is known from tackle2-hub
+	carol := Contributor{Login: "carol"}
+	ui.Contributors = append(ui.Contributors, carol)
+	ui.UncheckedContributors = []string{"carol", "jane"}
+
+	r := NewRelease("v0.8.0", []*Notes{hub, ui}, nil)
+	if len(r.Components) != 2 || r.Components[0].Previous != "v0.7.2" {
+		t.Fatalf("Unexpected components %+v", r.Components)
+	}
+	if len(r.NewContributors) != 2 || r.NewContributors[0].Login != "alice" || r.NewContributors[1].Login != "bob" {
+		t.Errorf("Expected alice and bob to be the new contributors but got %+v", r.NewContributors)
+	}
+	if fmt.Sprint(r.UncheckedContributors) != "[carol]" {
+		t.Errorf("Expected carol to be unchecked but got %v", r.UncheckedContributors)
+	}
+	for _, s := range r.Sections {
+		if s.Type == pr.FeaturePR && len(s.Components) != 2 {
+			t.Errorf("Expected features of both components but got %+v", s.Components)
+		}
+		if s.Type == pr.BugFixPR && (len(s.Components) != 1 || s.Components[0].Repo != "tackle2-hub") {
+			t.Errorf("Expected bug fixes of tackle2-hub only but got %+v", s.Components)
+		}
+	}
+
+	md := RenderReleaseMarkdown(r)
+	for _, want := range []string{
+		"# Konveyor v0.8.0\n",
+		"| [tackle2-hub](https://github.com/konveyor/tackle2-hub) | [v0.8.0](https://github.com/konveyor/tackle2-hub/releases/tag/v0.8.0) | v0.7.2 | [changes](https://github.com/konveyor/tackle2-hub/compare/v0.7.2...v0.8.0) |\n",
+		"| [tackle2-ui](https://github.com/konveyor/tackle2-ui) | [v0.8.0](https://github.com/konveyor/tackle2-ui/releases/tag/v0.8.0) | - |",
+		"## :sparkles: Features\n\n### [tackle2-hub](https://github.com/konveyor/tackle2-hub)\n* Add the report",
+		"### [tackle2-ui](https://github.com/konveyor/tackle2-ui)\n* Update README",
+		":warning: This list may be incomplete, it could not be checked if @carol made their first contribution.\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+}
+
+func TestFormatters(t *testing.T) {
+	TemplateDir = "../../templates"
+
+	p := pull(1, ":sparkles: [ui] Add the report", "Fixes #7\n\n```release-note\nThe report is shown in the UI.\n```")
+	p.Base = &github.PullRequestBranch{Repo: &github.Repository{
+		Owner: &github.User{Login: github.String("konveyor")},
+		Name:  github.String("tackle2-hub"),
+	}}
+	p.Labels = []*github.Label{{Name: github.String("kind/feature")}}
+	n := Build([]*github.PullRequest{p}, nil)
+	n.Org, n.Repo, n.From, n.To = "konveyor", "tackle2-hub", "v0.3.0", "v0.4.0"
+	r := NewRelease("v0.4.0", []*Notes{n}, nil)
+
+	testCases := []struct {
+		format string
+		want   []string
+	}{
+		{format: FormatMarkdown, want: []string{"## :sparkles: Features\n", "* [ui] Add the report by @jane"}},
+		{format: FormatYAML, want: []string{"number: 1", "type: feature", "- kind/feature", "repo: tackle2-hub"}},
+		{format: FormatHTML, want: []string{
+			`<a href="https://github.com/konveyor/tackle2-hub/pull/1">#1</a>`,
+			`<a href="https://github.com/konveyor/tackle2-hub/issues/7">konveyor/tackle2-hub#7</a>`,
+			`<span class="label">kind/feature</span>`,
+		}},
+	}
+	for _, tc := range testCases {
+		f, err := NewFormatter(tc.format)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, render := range []func() (string, error){
+			func() (string, error) { return f.Format(n) },
+			func() (string, error) { return f.FormatRelease(r) },
+		} {
+			out, err := render()
+			if err != nil {
+				t.Fatalf("Unexpected %s error: %v", tc.format, err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("Expected %s to contain %q, got:\n%s", tc.format, want, out)
+				}
+			}
+		}
+	}
+
+	f, _ := NewFormatter(FormatJSON)
+	out, err := f.Format(n)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded Notes
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("Unexpected error decoding JSON: %v", err)
+	}
+	entry := decoded.Sections[1].Entries[0]
+	if entry.Number != 1 || entry.Type != pr.FeaturePR || entry.Author != "jane" || len(entry.Issues) != 1 || entry.Issues[0].Number != 7 {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+
+	if _, err := NewFormatter("pdf"); err == nil {
+		t.Errorf("Expected an unknown format to be rejected")
+	}
+}
+
+func TestReplaceBackports(t *testing.T) {
+	original := pull(10, ":bug: Fix the table", "```release-note\nThe table is sorted again.\n```")
+	original.User = &github.User{Login: github.String("alice")}
+	backport := pull(20, ":bug: Fix the table", "")
+	backport.User = &github.User{Login: github.String("Cherry Picker")}
+	again := pull(21, ":bug: Fix the table", "")
+	other := pull(22, ":bug: Fix the form", "")
+
+	pulls, backports := replaceBackports(
+		[]*github.PullRequest{backport, again, other},
+		map[int]*github.PullRequest{20: original, 21: original},
+	)
+	n := Build(pulls, nil)
+	n.Org, n.Repo, n.From, n.To = "konveyor", "tackle2-hub", "v0.3.0", "v0.3.1"
+	linkBackports(n, backports)
+
+	var fixes []Entry
+	for _, s := range n.Sections {
+		if s.Type == pr.BugFixPR {
+			fixes = s.Entries
+		}
+	}
+	if len(fixes) != 2 {
+		t.Fatalf("Expected 2 bug fixes but got %+v", fixes)
+	}
+	if fixes[0].Number != 10 || fixes[0].Author != "alice" || fixes[0].BackportNumber != 20 || fixes[0].MergedAt != backport.GetMergedAt().Time {
+		t.Errorf("Expected #10 by alice backported in #20 but got %+v", fixes[0])
+	}
+	if fixes[1].Number != 22 || fixes[1].BackportNumber != 0 {
+		t.Errorf("Expected #22 without backport but got %+v", fixes[1])
+	}
+
+	md := RenderMarkdown(n)
+	want := "* Fix the table by @alice in https://github.com/konveyor/tackle2-hub/pull/10 (backport https://github.com/konveyor/tackle2-hub/pull/20)\n\n  The table is sorted again.\n"
+	if !strings.Contains(md, want) {
+		t.Errorf("Expected markdown to contain %q, got:\n%s", want, md)
+	}
+}
+
+func TestMergedPRs(t *testing.T) {
+	var server *httptest.Server
+	listed := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/konveyor/tackle2-hub/compare/v0.7.0...main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"commits": [{"sha": "aaa"}, {"sha": "bbb"}]}`)
+	})
+	mux.HandleFunc("/repos/konveyor/tackle2-hub/commits/v0.7.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"sha": "old", "commit": {"committer": {"date": "2024-01-10T00:00:00Z"}}}`)
+	})
+	mux.HandleFunc("/repos/konveyor/tackle2-hub/pulls", func(w http.ResponseWriter, r *http.Request) {
+		listed++
+		if r.URL.Query().Get("state") != "closed" || r.URL.Query().Get("sort") != "updated" {
+			http.Error(w, "bad query", http.StatusBadRequest)
+			return
+		}
+		// The listing stops at PRs updated before v0.7.0, not at the last page
+		w.Header().Set("Link", `<`+server.URL+r.URL.Path+`?page=2>; rel="next"`)
+		fmt.Fprint(w, `[
+			{"number": 3, "merge_commit_sha": "bbb", "merged_at": "2024-01-12T00:00:00Z", "updated_at": "2024-01-12T00:00:00Z"},
+			{"number": 4, "merge_commit_sha": "ccc", "merged_at": "2024-01-11T00:00:00Z", "updated_at": "2024-01-11T00:00:00Z"},
+			{"number": 5, "merge_commit_sha": "aaa", "updated_at": "2024-01-11T00:00:00Z"},
+			{"number": 2, "merge_commit_sha": "aaa", "merged_at": "2024-01-11T00:00:00Z", "updated_at": "2024-01-11T00:00:00Z"},
+			{"number": 1, "merge_commit_sha": "old", "merged_at": "2024-01-09T00:00:00Z", "updated_at": "2024-01-09T00:00:00Z"}
+		]`)
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	generator := NewGenerator(client, nil)
+
+	pulls, err := generator.MergedPRs(context.Background(), "konveyor", "tackle2-hub", "v0.7.0", "main")
+	if err != nil {
+		t.Fatalf("MergedPRs() error = %v", err)
+	}
+	var numbers []int
+	for _, pull := range pulls {
+		numbers = append(numbers, pull.GetNumber())
+	}
+	if fmt.Sprint(numbers) != "[3 2]" || listed != 1 {
+		t.Errorf("MergedPRs() = %v after %d pages, want [3 2] after 1 page", numbers, listed)
+	}
+}
+
+func TestNewContributors(t *testing.T) {
+	searches := make(map[string]int)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		author := ""
+		for _, term := range strings.Fields(r.URL.Query().Get("q")) {
+			if strings.HasPrefix(term, "author:") {
+				author = strings.TrimPrefix(term, "author:")
+			}
+		}
+		searches[author]++
+		switch {
+		// bob hits the rate limit once
+		case author == "bob" && searches[author] == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "You have exceeded a secondary rate limit", "documentation_url": "https://docs.github.com/rest/overview/resources-in-the-rest-api#secondary-rate-limits"}`)
+		case author == "bob":
+			fmt.Fprint(w, `{"total_count": 3}`)
+		case author == "carol":
+			http.Error(w, "boom", http.StatusInternalServerError)
+		default:
+			fmt.Fprint(w, `{"total_count": 0}`)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	generator := NewGenerator(client, nil)
+	generator.searchInterval = 0
+
+	contributors := []Contributor{{Login: "alice"}, {Login: "bob"}, {Login: "carol"}}
+	newContributors, unchecked, err := generator.newContributors(context.Background(), "konveyor", "tackle2-hub", contributors)
+	if err != nil {
+		t.Fatalf("newContributors() error = %v", err)
+	}
+	if len(newContributors) != 1 || newContributors[0].Login != "alice" {
+		t.Errorf("Expected alice to be the new contributor but got %+v", newContributors)
+	}
+	if fmt.Sprint(unchecked) != "[carol]" || searches["bob"] != 2 {
+		t.Errorf("Expected carol to be unchecked and bob to be retried but got %v after %d searches of bob", unchecked, searches["bob"])
+	}
+}
@@ -0,0 +1,245 @@
+package notes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/pr"
+	"github.com/konveyor/release-tools/pkg/semver"
+	"github.com/sirupsen/logrus"
+)
+
+// Release are the release notes of a Konveyor release across the repositories
+// that make it up
+type Release struct {
+	Version    string      `json:"version"`
+	Components []Component `json:"components"`
+	// Sections holds the entries of each PR type grouped by component
+	Sections     []ReleaseSection   `json:"sections"`
+	Unclassified []ComponentEntries `json:"unclassified,omitempty"`
+
+	// Contributors are the contributors of all components
+	Contributors []Contributor `json:"contributors,omitempty"`
+	// NewContributors are the contributors new to every component they
+	// contributed to, someone new to a component but active in another is
+	// not new to the project
+	NewContributors []Contributor `json:"new_contributors,omitempty"`
+	// UncheckedContributors are the logins of the contributors that could not
+	// be checked for being new to a component and are not known from another
+	UncheckedContributors []string `json:"unchecked_contributors,omitempty"`
+}
+
+// Component is a repository released as part of a Konveyor release
+type Component struct {
+	Org      string `json:"org"`
+	Repo     string `json:"repo"`
+	Version  string `json:"version"`
+	Previous string `json:"previous,omitempty"`
+}
+
+// ReleaseSection groups the entries of a PR type by component
+type ReleaseSection struct {
+	Type       pr.PRType          `json:"type"`
+	Title      string             `json:"title"`
+	Alias      string             `json:"alias"`
+	Emoji      string             `json:"emoji"`
+	Components []ComponentEntries `json:"components"`
+}
+
+// ComponentEntries are the entries of a component in a section
+type ComponentEntries struct {
+	Org     string  `json:"org"`
+	Repo    string  `json:"repo"`
+	Entries []Entry `json:"entries"`
+}
+
+// GenerateRelease returns the combined release notes of the repos tagged with
+// version, each starting at the previous tag of the repo. Repos without the
+// tag are left out.
+func (g *Generator) GenerateRelease(ctx context.Context, repos []config.Repo, version string) (*Release, error) {
+	v, err := semver.Parse(version)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []*Notes
+	for _, r := range repos {
+		tags, err := g.Tags(ctx, r.Org, r.Repo)
+		if err != nil {
+			logrus.WithError(err).Warnf("Failed to list tags of %s/%s", r.Org, r.Repo)
+			continue
+		}
+		if !contains(tags, version) {
+			logrus.Infof("Skipping %s/%s, it has no %s tag", r.Org, r.Repo, version)
+			continue
+		}
+		previous, _ := semver.Previous(tags, v)
+
+		n, err := g.Generate(ctx, r.Org, r.Repo, previous, version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate release notes of %s/%s: %w", r.Org, r.Repo, err)
+		}
+		all = append(all, n)
+	}
+
+	return NewRelease(version, all, g.registry), nil
+}
+
+// NewRelease combines the release notes of the components, entries are
+// grouped by PR type first and then by component
+func NewRelease(version string, all []*Notes, registry *pr.Registry) *Release {
+	r := &Release{Version: version}
+	sections := make(map[pr.PRType]int)
+	for _, t := range registry.Types() {
+		if t.Section == "" {
+			continue
+		}
+		sections[pr.PRType(t.Type)] = len(r.Sections)
+		r.Sections = append(r.Sections, ReleaseSection{
+			Type:  pr.PRType(t.Type),
+			Title: t.Section,
+			Alias: t.Alias,
+			Emoji: t.Emoji,
+		})
+	}
+
+	var contributors, newContributors [][]Contributor
+	for _, n := range all {
+		contributors = append(contributors, n.Contributors)
+		newContributors = append(newContributors, n.NewContributors)
+		r.Components = append(r.Components, Component{
+			Org:      n.Org,
+			Repo:     n.Repo,
+			Version:  n.To,
+			Previous: n.From,
+		})
+		for _, s := range n.Sections {
+			i, ok := sections[s.Type]
+			if !ok || len(s.Entries) == 0 {
+				continue
+			}
+			r.Sections[i].Components = append(r.Sections[i].Components, ComponentEntries{
+				Org:     n.Org,
+				Repo:    n.Repo,
+				Entries: s.Entries,
+			})
+		}
+		if len(n.Unclassified) > 0 {
+			r.Unclassified = append(r.Unclassified, ComponentEntries{
+				Org:     n.Org,
+				Repo:    n.Repo,
+				Entries: n.Unclassified,
+			})
+		}
+	}
+
+	r.Contributors = mergeContributors(contributors, true)
+	r.NewContributors, r.UncheckedContributors = newToAll(all, mergeContributors(newContributors, false))
+	return r
+}
+
+// newToAll keeps the new contributors that are new to every component they
+// contributed to. Contributors that could not be checked in a component are
+// left out and returned as unchecked, unless another component knows them.
+func newToAll(all []*Notes, newContributors []Contributor) ([]Contributor, []string) {
+	known := make(map[string]bool)
+	unchecked := make(map[string]bool)
+	for _, n := range all {
+		notKnown := make(map[string]bool)
+		for _, c := range n.NewContributors {
+			notKnown[c.Login] = true
+		}
+		for _, login := range n.UncheckedContributors {
+			notKnown[login] = true
+			unchecked[login] = true
+		}
+		for _, c := range n.Contributors {
+			if !notKnown[c.Login] {
+				known[c.Login] = true
+			}
+		}
+	}
+
+	var filtered []Contributor
+	for _, c := range newContributors {
+		if !known[c.Login] && !unchecked[c.Login] {
+			filtered = append(filtered, c)
+		}
+	}
+	var uncheckedLogins []string
+	for login := range unchecked {
+		if !known[login] {
+			uncheckedLogins = append(uncheckedLogins, login)
+		}
+	}
+	sort.Slice(uncheckedLogins, func(i, j int) bool {
+		return strings.ToLower(uncheckedLogins[i]) < strings.ToLower(uncheckedLogins[j])
+	})
+	return filtered, uncheckedLogins
+}
+
+// mergeContributors combines the contributors of the components, keeping the
+// first PR merged by each contributor and summing their PRs when sum is set
+func mergeContributors(lists [][]Contributor, sum bool) []Contributor {
+	byLogin := make(map[string]*Contributor)
+	var logins []string
+	for _, list := range lists {
+		for _, c := range list {
+			existing, ok := byLogin[c.Login]
+			if !ok {
+				c := c
+				byLogin[c.Login] = &c
+				logins = append(logins, c.Login)
+				continue
+			}
+			if sum {
+				existing.PRs += c.PRs
+			}
+			if c.firstMergedAt.Before(existing.firstMergedAt) {
+				existing.FirstPR, existing.firstMergedAt = c.FirstPR, c.firstMergedAt
+			}
+		}
+	}
+
+	sort.Slice(logins, func(i, j int) bool {
+		return strings.ToLower(logins[i]) < strings.ToLower(logins[j])
+	})
+	var merged []Contributor
+	for _, login := range logins {
+		merged = append(merged, *byLogin[login])
+	}
+	return merged
+}
+
+// Tags returns the names of the tags of the repo
+func (g *Generator) Tags(ctx context.Context, org, repo string) ([]string, error) {
+	var names []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		tags, resp, err := g.client.Repositories.ListTags(ctx, org, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+		for _, t := range tags {
+			names = append(names, t.GetName())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return names, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
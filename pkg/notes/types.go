@@ -0,0 +1,88 @@
+package notes
+
+import (
+	"time"
+
+	"github.com/konveyor/release-tools/pkg/pr"
+)
+
+// Notes are the release notes of a repository for a range of commits
+type Notes struct {
+	Org  string `json:"org"`
+	Repo string `json:"repo"`
+	// From is the previous release, empty when the range starts at the first
+	// commit
+	From string `json:"from,omitempty"`
+	// To is the release, or the ref it is cut from
+	To string `json:"to"`
+
+	// Sections holds the entries of each PR type that has a release note
+	// section, in the order of the PR type registry
+	Sections []Section `json:"sections"`
+	// Unclassified holds the PRs whose title has no PR type prefix
+	Unclassified []Entry `json:"unclassified,omitempty"`
+
+	// Contributors are the authors of the merged PRs, bots excluded
+	Contributors []Contributor `json:"contributors,omitempty"`
+	// NewContributors are the contributors whose first PR was merged in the
+	// range
+	NewContributors []Contributor `json:"new_contributors,omitempty"`
+	// UncheckedContributors are the logins of the contributors that could not
+	// be checked for being new, NewContributors may be missing some of them
+	UncheckedContributors []string `json:"unchecked_contributors,omitempty"`
+}
+
+// Contributor is the author of merged PRs
+type Contributor struct {
+	Login string `json:"login"`
+	// PRs is the number of PRs merged in the range
+	PRs int `json:"prs"`
+	// FirstPR links the first PR merged in the range
+	FirstPR string `json:"first_pr"`
+
+	firstMergedAt time.Time
+}
+
+// Section groups the entries of a PR type
+type Section struct {
+	Type pr.PRType `json:"type"`
+	// Title is the heading of the section, e.g. "Features"
+	Title string `json:"title"`
+	// Alias is the emoji alias of the PR type, e.g. ":sparkles:"
+	Alias string `json:"alias"`
+	// Emoji is the emoji the alias renders as, e.g. "✨"
+	Emoji   string  `json:"emoji"`
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is a merged PR in the release notes
+type Entry struct {
+	Number int       `json:"number"`
+	URL    string    `json:"url"`
+	Type   pr.PRType `json:"type"`
+	Scope  string    `json:"scope,omitempty"`
+	// Title is the PR title with the PR type prefix stripped
+	Title string `json:"title"`
+	// Note is the release note from the PR description, empty when there is
+	// none or it is NONE
+	Note   string   `json:"note,omitempty"`
+	Author string   `json:"author"`
+	Labels []string `json:"labels,omitempty"`
+	// Issues are the issues the PR closes
+	Issues   []pr.IssueRef `json:"issues,omitempty"`
+	MergedAt time.Time     `json:"merged_at"`
+	// BackportNumber and BackportURL point at the PR that cherry-picked the
+	// PR into a release-X.Y branch, the entry describes the original PR
+	BackportNumber int    `json:"backport_number,omitempty"`
+	BackportURL    string `json:"backport_url,omitempty"`
+}
+
+// Empty returns true if there is nothing to report
+func (n *Notes) Empty() bool {
+	for _, s := range n.Sections {
+		if len(s.Entries) > 0 {
+			return false
+		}
+	}
+	return len(n.Unclassified) == 0
+}
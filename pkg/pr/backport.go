@@ -0,0 +1,40 @@
+package pr
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// Matches the branches created by the cherry-pick workflow, like
+	// "cherry-pick-pr123-release-0.3"
+	cherryPickBranchRegex = regexp.MustCompile(`^cherry-pick-pr(\d+)-`)
+	// Matches PR descriptions pointing at the PR they backport, like
+	// "Backport of #123" or "Cherry-pick of #123"
+	backportBodyRegex = regexp.MustCompile(`(?i)\b(?:backport|cherry[- ]pick(?:ed)?)(?:\s+of|\s+from)?\s+#(\d+)\b`)
+	// Matches the trailer added by `git cherry-pick -x`
+	cherryPickTrailerRegex = regexp.MustCompile(`\(cherry picked from commit ([0-9a-f]{7,40})\)`)
+)
+
+// BackportOf returns the number of the PR a backport PR cherry-picks, from
+// the branch created by the cherry-pick workflow or the PR description.
+func BackportOf(headRef, body string) (int, bool) {
+	if m := cherryPickBranchRegex.FindStringSubmatch(strings.TrimPrefix(headRef, "refs/heads/")); m != nil {
+		return atoi(m[1]), true
+	}
+	body = htmlCommentRegex.ReplaceAllString(body, "")
+	if m := backportBodyRegex.FindStringSubmatch(body); m != nil {
+		return atoi(m[1]), true
+	}
+	return 0, false
+}
+
+// CherryPickedFrom returns the commits a commit message says it was
+// cherry-picked from.
+func CherryPickedFrom(message string) []string {
+	var shas []string
+	for _, m := range cherryPickTrailerRegex.FindAllStringSubmatch(message, -1) {
+		shas = append(shas, m[1])
+	}
+	return shas
+}
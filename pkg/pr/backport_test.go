@@ -0,0 +1,35 @@
+package pr
+
+import (
+	"testing"
+)
+
+func TestBackportOf(t *testing.T) {
+	testCases := []struct {
+		headRef  string
+		body     string
+		expected int
+		ok       bool
+	}{
+		{headRef: "cherry-pick-pr123-release-0.3", expected: 123, ok: true},
+		{headRef: "refs/heads/cherry-pick-pr45-release-0.4", expected: 45, ok: true},
+		{headRef: "backport-fix", body: "Backport of #67 to release-0.3", expected: 67, ok: true},
+		{headRef: "my-branch", body: "Cherry-picked from #89", expected: 89, ok: true},
+		{headRef: "my-branch", body: "<!-- Backport of #1 -->\nFixes #12", ok: false},
+	}
+
+	for _, tc := range testCases {
+		number, ok := BackportOf(tc.headRef, tc.body)
+		if number != tc.expected || ok != tc.ok {
+			t.Errorf("Expected %d, %v for %q, %q but got %d, %v", tc.expected, tc.ok, tc.headRef, tc.body, number, ok)
+		}
+	}
+}
+
+func TestCherryPickedFrom(t *testing.T) {
+	message := ":bug: Fix the table\n\n(cherry picked from commit 0123456789abcdef0123456789abcdef01234567)\nSigned-off-by: Jane Doe <jane@example.com>"
+	shas := CherryPickedFrom(message)
+	if len(shas) != 1 || shas[0] != "0123456789abcdef0123456789abcdef01234567" {
+		t.Errorf("Unexpected commits %v", shas)
+	}
+}
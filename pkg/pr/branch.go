@@ -0,0 +1,49 @@
+package pr
+
+import (
+	"strings"
+
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/semver"
+)
+
+// ReleaseBranchOverrideLabel is the default label that allows a feature or
+// breaking change to target a release branch anyway.
+const ReleaseBranchOverrideLabel = "backport/allow-feature"
+
+// IsReleaseBranch returns true for the release-X.Y branches described in
+// VERSIONING.md, other release- branches like release-notes-fix are not.
+func IsReleaseBranch(ref string) bool {
+	_, _, ok := semver.ReleaseBranch(ref)
+	return ok
+}
+
+// VerifyBaseBranch checks that the built-in PR type is allowed on the branch
+// it targets.
+func VerifyBaseBranch(prType PRType, baseRef string, labels []string, overrideLabel string) error {
+	var r *Registry
+	return r.VerifyBaseBranch(prType, baseRef, labels, overrideLabel)
+}
+
+// VerifyBaseBranch checks that the PR type is allowed on the branch it
+// targets. Release branches only receive backported fixes, so types with a
+// minor or major impact are rejected unless the PR has the override label.
+func (r *Registry) VerifyBaseBranch(prType PRType, baseRef string, labels []string, overrideLabel string) error {
+	if !IsReleaseBranch(baseRef) {
+		return nil
+	}
+	t, ok := r.Lookup(prType)
+	if !ok || (t.Impact != config.ImpactMinor && t.Impact != config.ImpactMajor) {
+		return nil
+	}
+	for _, label := range labels {
+		if overrideLabel != "" && label == overrideLabel {
+			return nil
+		}
+	}
+	return ReleaseBranchError{
+		prType:        prType,
+		branch:        strings.TrimPrefix(baseRef, "refs/heads/"),
+		overrideLabel: overrideLabel,
+	}
+}
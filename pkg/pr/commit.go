@@ -0,0 +1,92 @@
+package pr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Matches the trailer added by `git commit -s`
+var signOffRegex = regexp.MustCompile(`(?mi)^Signed-off-by:[ \t]*(.*?)[ \t]*<([^>]*)>[ \t]*$`)
+
+// Commit is a commit in a PR.
+type Commit struct {
+	SHA         string
+	Message     string
+	AuthorName  string
+	AuthorEmail string
+	// Parents is the number of parents, merge commits are not verified
+	Parents int
+}
+
+// Subject returns the first line of the commit message.
+func (c Commit) Subject() string {
+	subject, _, _ := strings.Cut(c.Message, "\n")
+	return strings.TrimSpace(subject)
+}
+
+// SignOff is a Signed-off-by trailer.
+type SignOff struct {
+	Name  string
+	Email string
+}
+
+func (s SignOff) String() string {
+	return fmt.Sprintf("%s <%s>", s.Name, s.Email)
+}
+
+// SignOffs returns the Signed-off-by trailers in a commit message.
+func SignOffs(message string) []SignOff {
+	var signOffs []SignOff
+	for _, m := range signOffRegex.FindAllStringSubmatch(message, -1) {
+		signOffs = append(signOffs, SignOff{Name: m[1], Email: strings.TrimSpace(m[2])})
+	}
+	return signOffs
+}
+
+// SignedOffByAuthor returns true if the commit has a Signed-off-by trailer
+// with the email of its author.
+func (c Commit) SignedOffByAuthor() bool {
+	for _, s := range SignOffs(c.Message) {
+		if strings.EqualFold(s.Email, c.AuthorEmail) {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyCommits checks every commit and reports all failing commits at once.
+// Sign-offs must match the commit author and, when checkPrefix is set, the
+// commit subject must have a PR type prefix.
+func (r *Registry) VerifyCommits(commits []Commit, checkSignOff, checkPrefix, allowConventional bool) error {
+	var failed []CommitError
+	for _, c := range commits {
+		if c.Parents > 1 {
+			continue
+		}
+
+		var problems []string
+		if checkSignOff && !c.SignedOffByAuthor() {
+			signOffs := SignOffs(c.Message)
+			if len(signOffs) == 0 {
+				problems = append(problems, "missing a `Signed-off-by` trailer")
+			} else {
+				problems = append(problems, fmt.Sprintf("`Signed-off-by: %s` does not match the author %#q", signOffs[0], c.AuthorName+" <"+c.AuthorEmail+">"))
+			}
+		}
+		if checkPrefix {
+			if _, err := r.ParseTitle(c.Subject(), allowConventional); err != nil {
+				problems = append(problems, "subject has no PR type prefix")
+			}
+		}
+
+		if len(problems) > 0 {
+			failed = append(failed, CommitError{SHA: c.SHA, Subject: c.Subject(), Problems: problems})
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	return CommitsError{Commits: failed, checkSignOff: checkSignOff, checkPrefix: checkPrefix}
+}
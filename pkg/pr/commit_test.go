@@ -0,0 +1,84 @@
+package pr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSignOffs(t *testing.T) {
+	message := "Fix the thing\n\nSigned-off-by: Jane Doe <jane@example.com>\nsigned-off-by:John <john@example.com> \n"
+	signOffs := SignOffs(message)
+	expected := []SignOff{
+		{Name: "Jane Doe", Email: "jane@example.com"},
+		{Name: "John", Email: "john@example.com"},
+	}
+	if len(signOffs) != len(expected) {
+		t.Fatalf("Expected %d sign-offs but got %d", len(expected), len(signOffs))
+	}
+	for i := range expected {
+		if signOffs[i] != expected[i] {
+			t.Errorf("Expected sign-off %v but got %v", expected[i], signOffs[i])
+		}
+	}
+}
+
+func TestVerifyCommits(t *testing.T) {
+	commits := []Commit{
+		{
+			SHA:         "1111111111",
+			Message:     ":sparkles: Good commit\n\nSigned-off-by: Jane Doe <Jane@Example.com>",
+			AuthorName:  "Jane Doe",
+			AuthorEmail: "jane@example.com",
+			Parents:     1,
+		},
+		{
+			SHA:         "2222222222",
+			Message:     "No sign-off",
+			AuthorName:  "Jane Doe",
+			AuthorEmail: "jane@example.com",
+			Parents:     1,
+		},
+		{
+			SHA:         "3333333333",
+			Message:     ":bug: Wrong sign-off\n\nSigned-off-by: Someone Else <else@example.com>",
+			AuthorName:  "Jane Doe",
+			AuthorEmail: "jane@example.com",
+			Parents:     1,
+		},
+		{
+			SHA:         "4444444444",
+			Message:     "Merge branch 'main'",
+			AuthorName:  "Jane Doe",
+			AuthorEmail: "jane@example.com",
+			Parents:     2,
+		},
+	}
+
+	var r *Registry
+	if err := r.VerifyCommits(commits[:1], true, true, false); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	err := r.VerifyCommits(commits, true, true, false)
+	commitsErr, ok := err.(CommitsError)
+	if !ok {
+		t.Fatalf("Expected CommitsError but got %T", err)
+	}
+	if len(commitsErr.Commits) != 2 {
+		t.Fatalf("Expected 2 failing commits but got %d", len(commitsErr.Commits))
+	}
+	if commitsErr.Commits[0].SHA != "2222222222" || len(commitsErr.Commits[0].Problems) != 2 {
+		t.Errorf("Expected 2222222222 to fail sign-off and prefix, got %+v", commitsErr.Commits[0])
+	}
+	if commitsErr.Commits[1].SHA != "3333333333" || len(commitsErr.Commits[1].Problems) != 1 {
+		t.Errorf("Expected 3333333333 to fail sign-off only, got %+v", commitsErr.Commits[1])
+	}
+	if !strings.Contains(err.Error(), "2222222 `No sign-off`") {
+		t.Errorf("Expected error to list the failing commit, got %q", err)
+	}
+
+	// Prefixes are not required unless asked for
+	if err := r.VerifyCommits(commits[2:3], false, false, false); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
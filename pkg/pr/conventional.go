@@ -0,0 +1,97 @@
+package pr
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Matches https://www.conventionalcommits.org prefixes like "feat: ",
+// "fix(ui): " or "feat!: "
+var conventionalRegex = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^)]*)\))?(!)?:\s*(\S.*)$`)
+
+// conventionalTypes maps Conventional Commit types onto the built-in PR types,
+// a registry type named after the Conventional Commit type takes precedence
+var conventionalTypes = map[string]PRType{
+	"feat":     FeaturePR,
+	"feature":  FeaturePR,
+	"fix":      BugFixPR,
+	"docs":     DocsPR,
+	"test":     TestPR,
+	"tests":    TestPR,
+	"build":    InfraPR,
+	"chore":    InfraPR,
+	"ci":       InfraPR,
+	"perf":     InfraPR,
+	"refactor": InfraPR,
+	"revert":   InfraPR,
+	"style":    InfraPR,
+}
+
+// Title is a PR title broken into its parts.
+type Title struct {
+	// Type is the type of PR
+	Type PRType
+	// Scope is the component the PR is about, from a "[tag]" prefix or a
+	// Conventional Commit scope
+	Scope string
+	// Title is the title without any prefixes
+	Title string
+	// Conventional is true if the type came from a Conventional Commit prefix
+	Conventional bool
+}
+
+// conventionalTitle is a title with a Conventional Commit prefix
+type conventionalTitle struct {
+	prType  PRType
+	scope   string
+	subject string
+}
+
+// conventionalType returns the PR type of the registry for a Conventional
+// Commit type: the type of the same name, e.g. "perf", or the built-in type
+// it maps onto when the registry has it
+func (r *Registry) conventionalType(name string) (PRType, bool) {
+	name = strings.ToLower(name)
+	if _, ok := r.Lookup(PRType(name)); ok {
+		return PRType(name), true
+	}
+	prType, ok := conventionalTypes[name]
+	if !ok {
+		return UnknownPR, false
+	}
+	_, ok = r.Lookup(prType)
+	return prType, ok
+}
+
+// parseConventional parses a Conventional Commit prefix from the title. The
+// breaking marker turns any type into a breaking change.
+func (r *Registry) parseConventional(title string) (conventionalTitle, bool) {
+	m := conventionalRegex.FindStringSubmatch(title)
+	if m == nil {
+		return conventionalTitle{}, false
+	}
+	prType, ok := r.conventionalType(m[1])
+	if !ok {
+		return conventionalTitle{}, false
+	}
+	if m[3] == "!" {
+		if _, ok := r.Lookup(BreakingPR); !ok {
+			return conventionalTitle{}, false
+		}
+		prType = BreakingPR
+	}
+	return conventionalTitle{
+		prType:  prType,
+		scope:   strings.TrimSpace(m[2]),
+		subject: strings.TrimSpace(m[4]),
+	}, true
+}
+
+// rewrite returns the title using the emoji alias for the PR type
+func (c conventionalTitle) rewrite(alias string) string {
+	// Only keep scopes that are also valid tags
+	if c.scope == "" || !tagRegex.MatchString("["+c.scope+"]") {
+		return alias + " " + c.subject
+	}
+	return "[" + c.scope + "] " + alias + " " + c.subject
+}
@@ -0,0 +1,139 @@
+package pr
+
+import (
+	"testing"
+
+	"github.com/konveyor/release-tools/pkg/config"
+)
+
+func TestParseTitleConventional(t *testing.T) {
+	testCases := []struct {
+		title              string
+		expected           Title
+		expectedSuggestion string
+	}{
+		{
+			title:              "feat: Add new feature",
+			expected:           Title{Type: FeaturePR, Title: "Add new feature", Conventional: true},
+			expectedSuggestion: ":sparkles: Add new feature",
+		},
+		{
+			title:              "fix(ui): Fix the table",
+			expected:           Title{Type: BugFixPR, Scope: "ui", Title: "Fix the table", Conventional: true},
+			expectedSuggestion: "[ui] :bug: Fix the table",
+		},
+		{
+			title:              "feat!: Drop the old API",
+			expected:           Title{Type: BreakingPR, Title: "Drop the old API", Conventional: true},
+			expectedSuggestion: ":warning: Drop the old API",
+		},
+		{
+			title:              "WIP: refactor(hub/api)!: Rework handlers",
+			expected:           Title{Type: BreakingPR, Scope: "hub/api", Title: "Rework handlers", Conventional: true},
+			expectedSuggestion: ":warning: Rework handlers",
+		},
+		{
+			title:              "[operator] chore: Bump deps",
+			expected:           Title{Type: InfraPR, Scope: "operator", Title: "Bump deps", Conventional: true},
+			expectedSuggestion: "[operator] :seedling: Bump deps",
+		},
+		{
+			title:              "Docs: Fix typo",
+			expected:           Title{Type: DocsPR, Title: "Fix typo", Conventional: true},
+			expectedSuggestion: ":book: Fix typo",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			var r *Registry
+
+			title, err := r.ParseTitle(tc.title, true)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if title != tc.expected {
+				t.Errorf("Expected %+v but got %+v", tc.expected, title)
+			}
+
+			_, err = r.ParseTitle(tc.title, false)
+			ccErr, ok := err.(ConventionalCommitError)
+			if !ok {
+				t.Fatalf("Expected ConventionalCommitError but got %T", err)
+			}
+			if ccErr.Suggestion() != tc.expectedSuggestion {
+				t.Errorf("Expected suggestion %q but got %q", tc.expectedSuggestion, ccErr.Suggestion())
+			}
+		})
+	}
+}
+
+func TestParseTitleNotConventional(t *testing.T) {
+	testCases := []struct {
+		title    string
+		expected Title
+	}{
+		{
+			title:    "[ui] :bug: Fix the table",
+			expected: Title{Type: BugFixPR, Scope: "ui", Title: "Fix the table"},
+		},
+		{
+			title:    ":sparkles: feat: Both prefixes",
+			expected: Title{Type: FeaturePR, Title: "feat: Both prefixes"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			var r *Registry
+			title, err := r.ParseTitle(tc.title, false)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if title != tc.expected {
+				t.Errorf("Expected %+v but got %+v", tc.expected, title)
+			}
+		})
+	}
+
+	// Unknown Conventional Commit types are not recognized
+	var r *Registry
+	_, err := r.ParseTitle("update: Something", true)
+	if _, ok := err.(PRTypeError); !ok {
+		t.Errorf("Expected PRTypeError but got %T", err)
+	}
+}
+
+func TestParseTitleConventionalRegistry(t *testing.T) {
+	c := config.DefaultPRTypes()
+	c.Types = append(c.Types, config.PRTypeConfig{Alias: ":rocket:", Type: "perf", Section: "Performance", Impact: config.ImpactPatch})
+	withPerf := NewRegistry(c)
+	featuresOnly := NewRegistry(&config.PRTypeRegistry{Types: []config.PRTypeConfig{
+		{Alias: ":sparkles:", Type: string(FeaturePR), Impact: config.ImpactMinor},
+	}})
+
+	testCases := []struct {
+		name     string
+		registry *Registry
+		title    string
+		expected PRType
+	}{
+		{name: "registry type", registry: withPerf, title: "perf: Cache the rules", expected: "perf"},
+		{name: "built-in mapping", registry: nil, title: "perf: Cache the rules", expected: InfraPR},
+		{name: "mapped type in registry", registry: featuresOnly, title: "feat: Add the report", expected: FeaturePR},
+		{name: "mapped type not in registry", registry: featuresOnly, title: "chore: Bump deps", expected: UnknownPR},
+		{name: "breaking not in registry", registry: featuresOnly, title: "feat!: Drop the API", expected: UnknownPR},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			title, err := tc.registry.ParseTitle(tc.title, true)
+			if title.Type != tc.expected {
+				t.Errorf("Expected PR type %q but got %q (%v)", tc.expected, title.Type, err)
+			}
+			if tc.expected == UnknownPR && err == nil {
+				t.Error("Expected an error for a type outside the registry")
+			}
+		})
+	}
+}
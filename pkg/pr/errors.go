@@ -2,36 +2,28 @@ package pr
 
 import (
 	"fmt"
+	"strings"
 )
 
-var emojiAliasMap = map[string]string{
-	emojiFeature:  PrefixFeature,
-	emojiBugFix:   PrefixBugFix,
-	emojiDocs:     PrefixDocs,
-	emojiInfra:    PrefixInfra,
-	emojiBreaking: PrefixBreaking,
-	emojiNoNote:   PrefixNoNote,
-}
-
 type PRTypeError struct {
-	title string
+	title    string
+	registry *Registry
 }
 
 func (e PRTypeError) Error() string {
+	var prefixes strings.Builder
+	for _, t := range e.registry.Types() {
+		fmt.Fprintf(&prefixes, "- %s: (%#q)\n", t.Description, t.Alias)
+	}
+
 	return fmt.Sprintf(`No matching PR type indicator found in title.
 
 I saw a title of %#q, which doesn't seem to have any of the acceptable prefixes.
 
 You need to have one of these as the prefix of your PR title:
-- Breaking change: (%#q)
-- Non-breaking feature: (%#q)
-- Bug fix: (%#q)
-- Docs: (%#q)
-- Infra/Tests/Other: (%#q)
-- No release note: (%#q)
-
+%s
 More details can be found at [konveyor/release-tools/VERSIONING.md](https://github.com/konveyor/release-tools/blob/main/VERSIONING.md).`,
-		e.title, PrefixBreaking, PrefixFeature, PrefixBugFix, PrefixDocs, PrefixInfra, PrefixNoNote)
+		e.title, prefixes.String())
 }
 
 type PRTypeUsedEmojiError struct {
@@ -40,10 +32,156 @@ type PRTypeUsedEmojiError struct {
 }
 
 func (e PRTypeUsedEmojiError) Error() string {
-	alias, _ := emojiAliasMap[string(e.emojiUsed)]
+	alias := e.registry.orDefault().emojiAliasMap[string(e.emojiUsed)]
 	return fmt.Sprintf(`Looks like you used an emoji character, %#q instead of it's alias %#q.
 
 Please use the alias %#q instead.
 
 %s`, e.emojiUsed, alias, alias, e.PRTypeError.Error())
 }
+
+type ConventionalCommitError struct {
+	PRTypeError
+	suggestion string
+}
+
+// Suggestion returns the title rewritten to use the emoji alias.
+func (e ConventionalCommitError) Suggestion() string {
+	return e.suggestion
+}
+
+func (e ConventionalCommitError) Error() string {
+	return fmt.Sprintf(`Looks like you used a Conventional Commits prefix in %#q.
+
+Please use the emoji alias instead, for example:
+
+    %s
+
+%s`, e.title, e.suggestion, e.PRTypeError.Error())
+}
+
+const releaseNoteHelp = "Add a release note to the PR description, either in a fenced block:\n\n" +
+	"    ```release-note\n" +
+	"    Describe the change for users.\n" +
+	"    ```\n\n" +
+	"or under a `## Release Note` heading."
+
+type ReleaseNoteMissingError struct {
+	prType PRType
+}
+
+func (e ReleaseNoteMissingError) Error() string {
+	return fmt.Sprintf(`No release note found in the PR description.
+
+PRs of type %#q must include a release note.
+
+%s`, e.prType, releaseNoteHelp)
+}
+
+type ReleaseNoteNoneError struct {
+	prType PRType
+	// allowed lists the aliases of the types that may use NONE
+	allowed string
+}
+
+func (e ReleaseNoteNoneError) Error() string {
+	return fmt.Sprintf(`A release note of %#q is only allowed for %s PRs.
+
+PRs of type %#q must describe the change for users.
+
+%s`, NoteNone, e.allowed, e.prType, releaseNoteHelp)
+}
+
+// CommitError is a commit that failed verification and why.
+type CommitError struct {
+	SHA      string
+	Subject  string
+	Problems []string
+}
+
+// CommitsError reports every commit in a PR that failed verification.
+type CommitsError struct {
+	Commits      []CommitError
+	checkSignOff bool
+	checkPrefix  bool
+}
+
+func (e CommitsError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d commit(s) in this PR failed verification:\n\n", len(e.Commits))
+	for _, c := range e.Commits {
+		sha := c.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		fmt.Fprintf(&b, "- %s %#q: %s\n", sha, c.Subject, strings.Join(c.Problems, "; "))
+	}
+	if e.checkSignOff {
+		b.WriteString("\nCommits must be signed off by their author ([DCO](https://developercertificate.org/)). " +
+			"Sign off with `git commit -s`, or fix existing commits with `git rebase --signoff <base branch>` and force push.\n")
+	}
+	if e.checkPrefix {
+		b.WriteString("\nCommit subjects must start with a PR type prefix. " +
+			"More details can be found at [konveyor/release-tools/VERSIONING.md](https://github.com/konveyor/release-tools/blob/main/VERSIONING.md).\n")
+	}
+	return b.String()
+}
+
+type ReleaseBranchError struct {
+	prType        PRType
+	branch        string
+	overrideLabel string
+}
+
+func (e ReleaseBranchError) Error() string {
+	override := ""
+	if e.overrideLabel != "" {
+		override = fmt.Sprintf("\n\nIf maintainers agreed to make an exception, add the %#q label to this PR.", e.overrideLabel)
+	}
+	return fmt.Sprintf(`PRs of type %#q can not target the release branch %#q.
+
+Release branches are considered stable and only receive backported fixes, no new features or breaking changes.
+Please target the main branch instead.%s
+
+More details can be found at [konveyor/release-tools/VERSIONING.md](https://github.com/konveyor/release-tools/blob/main/VERSIONING.md#branching).`,
+		e.prType, e.branch, override)
+}
+
+// LinkedIssueError is returned when a PR does not close a valid issue.
+type LinkedIssueError struct {
+	PRType PRType
+	// Problems explains why each linked issue was not accepted
+	Problems []string
+}
+
+func (e LinkedIssueError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "PRs of type %#q must be linked to an open issue.\n\n", e.PRType)
+	if len(e.Problems) == 0 {
+		b.WriteString("I couldn't find any closing keywords in the PR description.\n")
+	} else {
+		b.WriteString("None of the linked issues can be used:\n")
+		for _, p := range e.Problems {
+			fmt.Fprintf(&b, "- %s\n", p)
+		}
+	}
+	b.WriteString("\nLink the issue this PR resolves in the PR description using a closing keyword, for example " +
+		"`Fixes #12`, `Closes konveyor/tackle2-hub#34` or `Resolves https://github.com/konveyor/tackle2-ui/issues/56`.\n")
+	return b.String()
+}
+
+// TitleLintError is returned when the title breaks style rules reported as
+// errors.
+type TitleLintError struct {
+	Problems []LintProblem
+}
+
+func (e TitleLintError) Error() string {
+	var b strings.Builder
+	b.WriteString("The PR title does not follow the title style of this repository:\n\n")
+	for _, p := range e.Problems {
+		fmt.Fprintf(&b, "- %s\n", p)
+	}
+	b.WriteString("\nPlease update the PR title.\n")
+	return b.String()
+}
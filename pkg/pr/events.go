@@ -0,0 +1,115 @@
+package pr
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// Matches the branches GitHub creates for merge groups, like
+	// "refs/heads/gh-readonly-queue/main/pr-123-<sha>"
+	mergeQueueRegex = regexp.MustCompile(`^(?:refs/heads/)?gh-readonly-queue/(.+)/pr-(\d+)-[0-9a-f]+$`)
+	// Matches the PR number in commit subjects created by merging a PR, like
+	// "Merge pull request #12 from ..." or ":bug: Fix the thing (#12)"
+	mergeMessageRegex = regexp.MustCompile(`^(?:Merge pull request #(\d+) .*|.*\(#(\d+)\))$`)
+	// Matches the slash commands understood in PR comments
+	commandRegex = regexp.MustCompile(`(?m)^/(verify|retitle)(?:[ \t]+(.*?))?[ \t]*$`)
+)
+
+// Slash commands that can be commented on a PR
+const (
+	VerifyCommand  = "verify"
+	RetitleCommand = "retitle"
+)
+
+// MergeQueuePR returns the base branch and the number of the PR a merge group
+// branch was created for.
+func MergeQueuePR(ref string) (string, int, bool) {
+	m := mergeQueueRegex.FindStringSubmatch(ref)
+	if m == nil {
+		return "", 0, false
+	}
+	return m[1], atoi(m[2]), true
+}
+
+// PRFromMergeMessage returns the number of the PR merged by a commit, using
+// the subjects GitHub generates for merge and squash commits.
+func PRFromMergeMessage(message string) (int, bool) {
+	subject, _, _ := strings.Cut(message, "\n")
+	m := mergeMessageRegex.FindStringSubmatch(strings.TrimSpace(subject))
+	if m == nil {
+		return 0, false
+	}
+	if m[1] != "" {
+		return atoi(m[1]), true
+	}
+	return atoi(m[2]), true
+}
+
+// GroupCommit is a commit of a merge group.
+type GroupCommit struct {
+	SHA     string
+	Message string
+	// Parents are the SHAs of the parents, the first parent first
+	Parents []string
+}
+
+// MergeGroupPRs returns the numbers of the PRs in a merge group, the PR the
+// group branch was created for last. The PRs queued before it are only taken
+// from the merge commits on the first-parent history from head to base, as
+// the commits of PR branches may mention other PRs, e.g. cherry-picks.
+func MergeGroupPRs(headRef, head, base string, commits []GroupCommit) []int {
+	bySHA := make(map[string]GroupCommit, len(commits))
+	for _, c := range commits {
+		bySHA[c.SHA] = c
+	}
+
+	var numbers []int
+	seen := make(map[int]bool)
+	add := func(number int) {
+		if !seen[number] {
+			seen[number] = true
+			numbers = append(numbers, number)
+		}
+	}
+
+	// Walk from the head back to the base, then report oldest first
+	var merged []int
+	for sha := head; sha != base; {
+		c, ok := bySHA[sha]
+		if !ok || len(c.Parents) == 0 {
+			break
+		}
+		if len(c.Parents) > 1 {
+			if number, ok := PRFromMergeMessage(c.Message); ok {
+				merged = append(merged, number)
+			}
+		}
+		sha = c.Parents[0]
+	}
+	for i := len(merged) - 1; i >= 0; i-- {
+		add(merged[i])
+	}
+
+	if _, number, ok := MergeQueuePR(headRef); ok {
+		add(number)
+	}
+	return numbers
+}
+
+// Command is a slash command in a PR comment.
+type Command struct {
+	Name string
+	Args string
+}
+
+// CommandsFromComment returns the slash commands in a comment, one per line,
+// like "/verify" or "/retitle :bug: Fix the thing".
+func CommandsFromComment(body string) []Command {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	var commands []Command
+	for _, m := range commandRegex.FindAllStringSubmatch(body, -1) {
+		commands = append(commands, Command{Name: m[1], Args: m[2]})
+	}
+	return commands
+}
@@ -0,0 +1,77 @@
+package pr
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMergeQueuePR(t *testing.T) {
+	base, number, ok := MergeQueuePR("refs/heads/gh-readonly-queue/release-0.3/pr-123-0123456789abcdef0123456789abcdef01234567")
+	if !ok || base != "release-0.3" || number != 123 {
+		t.Errorf("Expected release-0.3 and 123 but got %q, %d, %v", base, number, ok)
+	}
+
+	if _, _, ok := MergeQueuePR("refs/heads/main"); ok {
+		t.Errorf("Expected refs/heads/main not to be a merge queue branch")
+	}
+}
+
+func TestPRFromMergeMessage(t *testing.T) {
+	testCases := []struct {
+		message  string
+		expected int
+		ok       bool
+	}{
+		{message: "Merge pull request #12 from someone/branch\n\n:bug: Fix the thing", expected: 12, ok: true},
+		{message: ":bug: Fix the thing (#34)\n\nSigned-off-by: Jane Doe <jane@example.com>", expected: 34, ok: true},
+		{message: ":bug: Fix the thing", ok: false},
+	}
+
+	for _, tc := range testCases {
+		number, ok := PRFromMergeMessage(tc.message)
+		if ok != tc.ok || number != tc.expected {
+			t.Errorf("Expected %d, %v for %q but got %d, %v", tc.expected, tc.ok, tc.message, number, ok)
+		}
+	}
+}
+
+func TestCommandsFromComment(t *testing.T) {
+	body := "Looks good.\r\n/retitle :bug: Fix the thing \r\n\r\n/verify\r\nnot /verify"
+	commands := CommandsFromComment(body)
+	expected := []Command{
+		{Name: RetitleCommand, Args: ":bug: Fix the thing"},
+		{Name: VerifyCommand},
+	}
+	if len(commands) != len(expected) {
+		t.Fatalf("Expected %d commands but got %d: %v", len(expected), len(commands), commands)
+	}
+	for i := range expected {
+		if commands[i] != expected[i] {
+			t.Errorf("Expected command %v but got %v", expected[i], commands[i])
+		}
+	}
+}
+
+func TestMergeGroupPRs(t *testing.T) {
+	headRef := "refs/heads/gh-readonly-queue/main/pr-30-0123456789abcdef0123456789abcdef01234567"
+	commits := []GroupCommit{
+		// A commit of the branch of #20, cherry-picked from #5
+		{SHA: "pick", Message: ":bug: Fix the thing (#5)", Parents: []string{"base"}},
+		{SHA: "merge20", Message: "Merge pull request #20 from someone/branch", Parents: []string{"base", "pick"}},
+		{SHA: "commit30", Message: ":sparkles: Add the thing (#31)", Parents: []string{"merge20"}},
+		{SHA: "merge30", Message: "Merge pull request #30 from someone/other", Parents: []string{"merge20", "commit30"}},
+	}
+
+	numbers := MergeGroupPRs(headRef, "merge30", "base", commits)
+	if fmt.Sprint(numbers) != "[20 30]" {
+		t.Errorf("Expected [20 30] but got %v", numbers)
+	}
+
+	// A squash merge group only has the PR of its branch
+	numbers = MergeGroupPRs(headRef, "squash", "base", []GroupCommit{
+		{SHA: "squash", Message: ":bug: Fix the thing (#5)", Parents: []string{"base"}},
+	})
+	if fmt.Sprint(numbers) != "[30]" {
+		t.Errorf("Expected [30] but got %v", numbers)
+	}
+}
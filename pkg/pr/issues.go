@@ -0,0 +1,82 @@
+package pr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/konveyor/release-tools/pkg/config"
+)
+
+// Matches GitHub closing keywords followed by an issue reference, like
+// "Fixes #12", "Closes konveyor/tackle2-hub#34" or
+// "Resolves https://github.com/konveyor/tackle2-ui/issues/56"
+var closingRegex = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?[ \t]+` +
+	`(?:https://github\.com/([\w.-]+)/([\w.-]+)/issues/(\d+)|([\w.-]+)/([\w.-]+)#(\d+)|#(\d+))\b`)
+
+// IssueRef identifies an issue in a repository.
+type IssueRef struct {
+	Org    string `json:"org"`
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+}
+
+func (i IssueRef) String() string {
+	return fmt.Sprintf("%s/%s#%d", i.Org, i.Repo, i.Number)
+}
+
+// URL returns the link to the issue on GitHub.
+func (i IssueRef) URL() string {
+	return fmt.Sprintf("https://github.com/%s/%s/issues/%d", i.Org, i.Repo, i.Number)
+}
+
+// LinkedIssues returns the issues a PR body closes with closing keywords.
+// References without a repository, like "#12", are in org/repo.
+func LinkedIssues(body, org, repo string) []IssueRef {
+	body = htmlCommentRegex.ReplaceAllString(body, "")
+
+	var issues []IssueRef
+	seen := make(map[IssueRef]bool)
+	for _, m := range closingRegex.FindAllStringSubmatch(body, -1) {
+		issue := IssueRef{Org: org, Repo: repo}
+		switch {
+		case m[3] != "":
+			issue.Org, issue.Repo, issue.Number = m[1], m[2], atoi(m[3])
+		case m[6] != "":
+			issue.Org, issue.Repo, issue.Number = m[4], m[5], atoi(m[6])
+		default:
+			issue.Number = atoi(m[7])
+		}
+		if issue.Number == 0 || seen[issue] {
+			continue
+		}
+		seen[issue] = true
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// LinkedIssueRequired returns true if PRs of the given built-in type must
+// close an issue.
+func LinkedIssueRequired(prType PRType) bool {
+	var r *Registry
+	return r.LinkedIssueRequired(prType)
+}
+
+// LinkedIssueRequired returns true if PRs of the given type must close an
+// issue, i.e. the type is a feature or a fix by its semantic version impact.
+func (r *Registry) LinkedIssueRequired(prType PRType) bool {
+	t, ok := r.Lookup(prType)
+	return ok && (t.Impact == config.ImpactMinor || t.Impact == config.ImpactPatch)
+}
+
+// SameRepo returns true if the issue is in org/repo, ignoring case.
+func (i IssueRef) SameRepo(org, repo string) bool {
+	return strings.EqualFold(i.Org, org) && strings.EqualFold(i.Repo, repo)
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
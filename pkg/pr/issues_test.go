@@ -0,0 +1,33 @@
+package pr
+
+import (
+	"testing"
+)
+
+func TestLinkedIssues(t *testing.T) {
+	body := `This does a thing.
+
+Fixes #12
+closes konveyor/tackle2-hub#34
+Resolves: https://github.com/konveyor/tackle2-ui/issues/56
+Fixed #12
+Related to #99
+<!-- Fixes #100 -->
+Prefixes #7 are not keywords`
+
+	expected := []IssueRef{
+		{Org: "konveyor", Repo: "analyzer-lsp", Number: 12},
+		{Org: "konveyor", Repo: "tackle2-hub", Number: 34},
+		{Org: "konveyor", Repo: "tackle2-ui", Number: 56},
+	}
+
+	issues := LinkedIssues(body, "konveyor", "analyzer-lsp")
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %v but got %v", expected, issues)
+	}
+	for i := range expected {
+		if issues[i] != expected[i] {
+			t.Errorf("Expected issue %v but got %v", expected[i], issues[i])
+		}
+	}
+}
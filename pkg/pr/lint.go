@@ -0,0 +1,149 @@
+package pr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/konveyor/release-tools/pkg/config"
+)
+
+// LintRule names a PR title style rule, as used in the warnings of
+// config.TitleLintConfig.
+type LintRule string
+
+const (
+	MinLengthRule           LintRule = config.LintMinLength
+	MaxLengthRule           LintRule = config.LintMaxLength
+	TrailingPunctuationRule LintRule = config.LintNoTrailingPunctuation
+	LeadingLowercaseRule    LintRule = config.LintNoLeadingLowercase
+	BannedWordsRule         LintRule = config.LintBannedWords
+	TicketOnlyRule          LintRule = config.LintNoTicketOnly
+)
+
+// LintSeverity is how a broken rule is reported.
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+)
+
+const trailingPunctuation = ".,;:!"
+
+var (
+	// Matches issue references, like "#12", "konveyor/tackle2-hub#34", links
+	// or Jira style keys like "MTA-1234"
+	ticketRegex = regexp.MustCompile(`https?://\S+|[\w.-]+/[\w.-]+#\d+|#\d+|\b[A-Z][A-Z0-9]+-\d+\b`)
+	// Matches the words that usually surround issue references, what is left
+	// of a title once these and the references are removed tells what the PR
+	// does
+	ticketWordsRegex = regexp.MustCompile(`(?i)\b(?:fix(?:e[sd])?|close[sd]?|resolve[sd]?|issues?|and|see)\b`)
+)
+
+// LintProblem is a title style rule the title breaks.
+type LintProblem struct {
+	Rule     LintRule
+	Severity LintSeverity
+	Message  string
+}
+
+func (p LintProblem) String() string {
+	return fmt.Sprintf("%s (`%s`)", p.Message, p.Rule)
+}
+
+// LintTitle checks the title, with its PR type prefix already stripped,
+// against the rules enabled in cfg. A nil cfg enables no rules.
+func LintTitle(title string, cfg *config.TitleLintConfig) []LintProblem {
+	if cfg == nil {
+		return nil
+	}
+
+	warnings := make(map[LintRule]bool)
+	for _, w := range cfg.Warnings {
+		warnings[LintRule(w)] = true
+	}
+
+	var problems []LintProblem
+	report := func(rule LintRule, format string, args ...any) {
+		severity := LintError
+		if warnings[rule] {
+			severity = LintWarning
+		}
+		problems = append(problems, LintProblem{Rule: rule, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	title = strings.TrimSpace(title)
+	length := utf8.RuneCountInString(title)
+	if cfg.MinLength > 0 && length < cfg.MinLength {
+		report(MinLengthRule, "The title is %d characters long, describe the change in at least %d characters", length, cfg.MinLength)
+	}
+	if cfg.MaxLength > 0 && length > cfg.MaxLength {
+		report(MaxLengthRule, "The title is %d characters long, shorten it to at most %d characters and move details to the PR description", length, cfg.MaxLength)
+	}
+
+	if cfg.NoTrailingPunctuation && title != "" {
+		last, _ := utf8.DecodeLastRuneInString(title)
+		if strings.ContainsRune(trailingPunctuation, last) {
+			report(TrailingPunctuationRule, "The title ends with %#q, remove the trailing punctuation", string(last))
+		}
+	}
+
+	if cfg.NoLeadingLowercase && startsLowercase(title) {
+		report(LeadingLowercaseRule, "The title starts with a lowercase word, capitalize it: %#q", capitalize(title))
+	}
+
+	if banned := bannedWords(title, cfg.BannedWords); len(banned) > 0 {
+		report(BannedWordsRule, "The title contains %s, remove or rephrase", strings.Join(banned, ", "))
+	}
+
+	if cfg.NoTicketOnly && ticketOnly(title) {
+		report(TicketOnlyRule, "The title only references an issue, describe the change instead and link the issue in the PR description")
+	}
+
+	return problems
+}
+
+// startsLowercase returns true if the first word is all lowercase, so names
+// like "gRPC" or "iOS" are accepted
+func startsLowercase(title string) bool {
+	first, _, _ := strings.Cut(title, " ")
+	r, _ := utf8.DecodeRuneInString(first)
+	if !unicode.IsLower(r) {
+		return false
+	}
+	return strings.IndexFunc(first, unicode.IsUpper) < 0
+}
+
+func capitalize(title string) string {
+	r, size := utf8.DecodeRuneInString(title)
+	return string(unicode.ToUpper(r)) + title[size:]
+}
+
+// bannedWords returns the banned words found as whole words in the title,
+// ignoring case
+func bannedWords(title string, banned []string) []string {
+	var found []string
+	for _, word := range banned {
+		if word == "" {
+			continue
+		}
+		wordRegex := regexp.MustCompile(`(?i)(?:^|\W)` + regexp.QuoteMeta(word) + `(?:$|\W)`)
+		if wordRegex.MatchString(title) {
+			found = append(found, fmt.Sprintf("%#q", word))
+		}
+	}
+	return found
+}
+
+// ticketOnly returns true if nothing describing the change is left once
+// issue references are removed from the title
+func ticketOnly(title string) bool {
+	if !ticketRegex.MatchString(title) {
+		return false
+	}
+	rest := ticketWordsRegex.ReplaceAllString(ticketRegex.ReplaceAllString(title, ""), "")
+	return strings.IndexFunc(rest, unicode.IsLetter) < 0
+}
@@ -0,0 +1,89 @@
+package pr
+
+import (
+	"testing"
+
+	"github.com/konveyor/release-tools/pkg/config"
+)
+
+func TestLintTitle(t *testing.T) {
+	cfg := &config.TitleLintConfig{
+		MinLength:             10,
+		MaxLength:             40,
+		NoTrailingPunctuation: true,
+		NoLeadingLowercase:    true,
+		BannedWords:           []string{"WIP", "tmp"},
+		NoTicketOnly:          true,
+		Warnings:              []string{string(MaxLengthRule)},
+	}
+
+	testCases := []struct {
+		title    string
+		expected []LintProblem
+	}{
+		{title: "Add the analysis report to the UI"},
+		{title: "gRPC provider handles timeouts"},
+		{
+			title: "fix.",
+			expected: []LintProblem{
+				{Rule: MinLengthRule, Severity: LintError},
+				{Rule: TrailingPunctuationRule, Severity: LintError},
+				{Rule: LeadingLowercaseRule, Severity: LintError},
+			},
+		},
+		{
+			title: "WIP WIP tmp changes",
+			expected: []LintProblem{
+				{Rule: BannedWordsRule, Severity: LintError},
+			},
+		},
+		{
+			title: "Rework the way the hub schedules tasks for all the addons",
+			expected: []LintProblem{
+				{Rule: MaxLengthRule, Severity: LintWarning},
+			},
+		},
+		{
+			title: "Fixes MTA-1234 and #56",
+			expected: []LintProblem{
+				{Rule: TicketOnlyRule, Severity: LintError},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		problems := LintTitle(tc.title, cfg)
+		if len(problems) != len(tc.expected) {
+			t.Errorf("Expected %d problems for %q but got %v", len(tc.expected), tc.title, problems)
+			continue
+		}
+		for i := range tc.expected {
+			if problems[i].Rule != tc.expected[i].Rule || problems[i].Severity != tc.expected[i].Severity {
+				t.Errorf("Expected %s %s for %q but got %s %s", tc.expected[i].Severity, tc.expected[i].Rule, tc.title, problems[i].Severity, problems[i].Rule)
+			}
+		}
+	}
+
+	if problems := LintTitle("fix.", nil); problems != nil {
+		t.Errorf("Expected no problems without config but got %v", problems)
+	}
+}
+
+func TestLintWarningsUseConfigKeys(t *testing.T) {
+	cfg := &config.TitleLintConfig{
+		NoTrailingPunctuation: true,
+		Warnings:              []string{"no_trailing_punctuation"},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	problems := LintTitle("Add the thing.", cfg)
+	if len(problems) != 1 || problems[0].Severity != LintWarning {
+		t.Errorf("Expected a trailing punctuation warning but got %v", problems)
+	}
+
+	cfg.Warnings = []string{"trailing_punctuation"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected an error for an unknown rule in warnings")
+	}
+}
@@ -0,0 +1,118 @@
+package pr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/konveyor/release-tools/pkg/config"
+)
+
+// NoteNone is the release note used to explicitly opt out of a release note.
+const NoteNone = "NONE"
+
+var (
+	// A fenced block like:
+	//   ```release-note
+	//   Some text
+	//   ```
+	noteBlockRegex = regexp.MustCompile("(?s)```release-note[ \\t]*\\n(.*?)```")
+	// A markdown heading like "## Release Note" or "### Release Notes"
+	noteHeadingRegex = regexp.MustCompile(`(?im)^#{1,6}[ \t]+release[ \t]+notes?[ \t]*$`)
+	anyHeadingRegex  = regexp.MustCompile(`(?m)^#{1,6}[ \t]+\S`)
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+)
+
+// NoteFromBody returns the release note found in the body of a PR and whether
+// one was found at all. A fenced ```release-note block takes precedence over a
+// "## Release Note" section.
+func NoteFromBody(body string) (string, bool) {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	body = htmlCommentRegex.ReplaceAllString(body, "")
+
+	if m := noteBlockRegex.FindStringSubmatch(body); m != nil {
+		note := strings.TrimSpace(m[1])
+		return note, note != ""
+	}
+
+	loc := noteHeadingRegex.FindStringIndex(body)
+	if loc == nil {
+		return "", false
+	}
+	section := body[loc[1]:]
+	if next := anyHeadingRegex.FindStringIndex(section); next != nil {
+		section = section[:next[0]]
+	}
+	note := strings.TrimSpace(section)
+	return note, note != ""
+}
+
+// IsNoneNote returns true if the note opts out of a release note.
+func IsNoneNote(note string) bool {
+	return strings.EqualFold(strings.TrimSpace(note), NoteNone)
+}
+
+// NoteRequired returns true if PRs of the given built-in type must carry a
+// release note.
+func NoteRequired(prType PRType) bool {
+	var r *Registry
+	return r.NoteRequired(prType)
+}
+
+// NoteRequired returns true if PRs of the given type must carry a release
+// note, i.e. the type has a semantic version impact.
+func (r *Registry) NoteRequired(prType PRType) bool {
+	t, ok := r.Lookup(prType)
+	return ok && t.Impact != "" && t.Impact != config.ImpactNone
+}
+
+// NoneNoteAllowed returns true if PRs of the given built-in type may opt out
+// of a release note with NONE.
+func NoneNoteAllowed(prType PRType) bool {
+	var r *Registry
+	return r.NoneNoteAllowed(prType)
+}
+
+// NoneNoteAllowed returns true if PRs of the given type may opt out of a
+// release note with NONE, i.e. the type has no semantic version impact.
+func (r *Registry) NoneNoteAllowed(prType PRType) bool {
+	t, ok := r.Lookup(prType)
+	return ok && (t.Impact == "" || t.Impact == config.ImpactNone)
+}
+
+// noneNoteAliases returns the aliases of the types that may opt out of a
+// release note with NONE, e.g. "`:book:`, `:seedling:` or `:ghost:`"
+func (r *Registry) noneNoteAliases() string {
+	var aliases []string
+	for _, t := range r.Types() {
+		if r.NoneNoteAllowed(PRType(t.Type)) {
+			aliases = append(aliases, fmt.Sprintf("%#q", t.Alias))
+		}
+	}
+	if len(aliases) < 2 {
+		return strings.Join(aliases, "")
+	}
+	return strings.Join(aliases[:len(aliases)-1], ", ") + " or " + aliases[len(aliases)-1]
+}
+
+// ValidateNote checks the release note found in a PR body against the rules
+// for the built-in type of PR.
+func ValidateNote(prType PRType, note string) error {
+	var r *Registry
+	return r.ValidateNote(prType, note)
+}
+
+// ValidateNote checks the release note found in a PR body against the rules
+// for the type of PR.
+func (r *Registry) ValidateNote(prType PRType, note string) error {
+	if note == "" {
+		if r.NoteRequired(prType) {
+			return ReleaseNoteMissingError{prType: prType}
+		}
+		return nil
+	}
+	if IsNoneNote(note) && !r.NoneNoteAllowed(prType) {
+		return ReleaseNoteNoneError{prType: prType, allowed: r.noneNoteAliases()}
+	}
+	return nil
+}
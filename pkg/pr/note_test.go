@@ -0,0 +1,103 @@
+package pr
+
+import (
+	"testing"
+)
+
+func TestNoteFromBody(t *testing.T) {
+	testCases := []struct {
+		name          string
+		body          string
+		expectedNote  string
+		expectedFound bool
+	}{
+		{
+			name:          "empty body",
+			body:          "",
+			expectedNote:  "",
+			expectedFound: false,
+		},
+		{
+			name:          "fenced block",
+			body:          "Some context\n\n```release-note\nAdds the thing.\n```\n",
+			expectedNote:  "Adds the thing.",
+			expectedFound: true,
+		},
+		{
+			name:          "fenced block with CRLF",
+			body:          "```release-note\r\nAdds the thing.\r\nAnd more.\r\n```",
+			expectedNote:  "Adds the thing.\nAnd more.",
+			expectedFound: true,
+		},
+		{
+			name:          "empty fenced block",
+			body:          "```release-note\n```",
+			expectedNote:  "",
+			expectedFound: false,
+		},
+		{
+			name:          "section",
+			body:          "## Summary\nStuff\n\n## Release Note\n\nFixed the bug.\n\n## Testing\nRan it",
+			expectedNote:  "Fixed the bug.",
+			expectedFound: true,
+		},
+		{
+			name:          "section with template comment",
+			body:          "### Release notes\n<!-- Describe the change -->\nNONE\n",
+			expectedNote:  "NONE",
+			expectedFound: true,
+		},
+		{
+			name:          "fenced block wins over section",
+			body:          "## Release Note\nsection\n\n```release-note\nblock\n```",
+			expectedNote:  "block",
+			expectedFound: true,
+		},
+		{
+			name:          "no note",
+			body:          "## Summary\nNothing to see here",
+			expectedNote:  "",
+			expectedFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			note, found := NoteFromBody(tc.body)
+			if note != tc.expectedNote {
+				t.Errorf("Expected note %q but got %q", tc.expectedNote, note)
+			}
+			if found != tc.expectedFound {
+				t.Errorf("Expected found %v but got %v", tc.expectedFound, found)
+			}
+		})
+	}
+}
+
+func TestValidateNote(t *testing.T) {
+	testCases := []struct {
+		prType        PRType
+		note          string
+		expectedError error
+	}{
+		{prType: FeaturePR, note: "Adds the thing.", expectedError: nil},
+		{prType: FeaturePR, note: "", expectedError: ReleaseNoteMissingError{prType: FeaturePR}},
+		{prType: BugFixPR, note: "", expectedError: ReleaseNoteMissingError{prType: BugFixPR}},
+		{prType: BreakingPR, note: "none", expectedError: ReleaseNoteNoneError{prType: BreakingPR, allowed: "`:book:`, `:seedling:`, `:test_tube:` or `:ghost:`"}},
+		{prType: NoNotePR, note: "NONE", expectedError: nil},
+		{prType: InfraPR, note: "NONE", expectedError: nil},
+		{prType: InfraPR, note: "", expectedError: nil},
+		{prType: DocsPR, note: "", expectedError: nil},
+		{prType: DocsPR, note: "NONE", expectedError: nil},
+		{prType: TestPR, note: "NONE", expectedError: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.prType)+"/"+tc.note, func(t *testing.T) {
+			err := ValidateNote(tc.prType, tc.note)
+			if err != tc.expectedError {
+				t.Errorf("Expected error %q but got %q", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -4,7 +4,10 @@ package pr
 
 import (
 	"regexp"
+	"sort"
 	"strings"
+
+	"github.com/konveyor/release-tools/pkg/config"
 )
 
 type PRType string
@@ -19,6 +22,7 @@ const (
 	NoNotePR   PRType = "nonote"
 	TestPR     PRType = "test"
 
+	// The prefixes of the built-in PR types, see pkg/config/pr-types.yaml.
 	// TODO(djzager): Should we allow emoji?
 	PrefixFeature  string = ":sparkles:"
 	PrefixBugFix   string = ":bug:"
@@ -27,14 +31,6 @@ const (
 	PrefixBreaking string = ":warning:"
 	PrefixNoNote   string = ":ghost:"
 	PrefixTestTube string = ":test_tube:"
-
-	emojiFeature  = string('✨')
-	emojiBugFix   = string('🐛')
-	emojiDocs     = string('📖')
-	emojiInfra    = string('🌱')
-	emojiBreaking = string('⚠')
-	emojiNoNote   = string('👻')
-	emojiTestTube = string('🧪')
 )
 
 // Extracted from kubernetes/test-infra/prow/plugins/wip/wip-label.go
@@ -42,59 +38,128 @@ var wipRegex = regexp.MustCompile(`(?i)^\W?WIP\W`)
 
 var tagRegex = regexp.MustCompile(`^\[[\w-\.]*\]`)
 
-// TypeFromTitle returns the type of PR and the title without prefix.
+// Registry recognizes PR types from the prefixes of PR titles.
+// A nil *Registry uses the built-in PR types.
+type Registry struct {
+	// types in the order they were configured, used for help text
+	types []config.PRTypeConfig
+	// types ordered by longest alias first, used for matching
+	byAlias []config.PRTypeConfig
+	// emoji character -> alias
+	emojiAliasMap map[string]string
+}
+
+var defaultRegistry = NewRegistry(config.DefaultPRTypes())
+
+// NewRegistry returns a Registry for the PR types in the config.
+func NewRegistry(c *config.PRTypeRegistry) *Registry {
+	r := &Registry{
+		types:         c.Types,
+		emojiAliasMap: make(map[string]string),
+	}
+	r.byAlias = append(r.byAlias, c.Types...)
+	sort.SliceStable(r.byAlias, func(i, j int) bool {
+		return len(r.byAlias[i].Alias) > len(r.byAlias[j].Alias)
+	})
+	for _, t := range c.Types {
+		if t.Emoji != "" {
+			r.emojiAliasMap[t.Emoji] = t.Alias
+		}
+	}
+	return r
+}
+
+func (r *Registry) orDefault() *Registry {
+	if r == nil {
+		return defaultRegistry
+	}
+	return r
+}
+
+// Types returns the configured PR types.
+func (r *Registry) Types() []config.PRTypeConfig {
+	return r.orDefault().types
+}
+
+// Lookup returns the configuration for a PR type.
+func (r *Registry) Lookup(prType PRType) (config.PRTypeConfig, bool) {
+	for _, t := range r.orDefault().types {
+		if PRType(t.Type) == prType {
+			return t, true
+		}
+	}
+	return config.PRTypeConfig{}, false
+}
+
+// TypeFromTitle returns the type of PR and the title without prefix using the
+// built-in PR types.
 func TypeFromTitle(title string) (PRType, string, error) {
+	var r *Registry
+	return r.TypeFromTitle(title)
+}
+
+// TypeFromTitle returns the type of PR and the title without prefix.
+func (r *Registry) TypeFromTitle(title string) (PRType, string, error) {
+	t, err := r.ParseTitle(title, false)
+	return t.Type, t.Title, err
+}
+
+// ParseTitle returns the type, scope and title without prefix. When
+// allowConventional is false, a Conventional Commit prefix results in a
+// ConventionalCommitError suggesting the emoji alias to use instead.
+func (r *Registry) ParseTitle(title string, allowConventional bool) (Title, error) {
 	// Remove the WIP prefix if found.
 	title = wipRegex.ReplaceAllString(title, "")
 
 	// Trim to remove spaces after WIP.
 	title = strings.TrimSpace(title)
 
-	// Remove a tag prefix if found.
-	title = tagRegex.ReplaceAllString(title, "")
+	// Remove a tag prefix if found, it is the scope of the PR.
+	var scope string
+	if tag := tagRegex.FindString(title); tag != "" {
+		scope = strings.Trim(tag, "[]")
+		title = strings.TrimPrefix(title, tag)
+	}
 	title = strings.TrimSpace(title)
 
 	if len(title) == 0 {
-		return UnknownPR, title, PRTypeError{title: title}
+		return Title{Scope: scope, Title: title}, PRTypeError{title: title, registry: r}
 	}
 
 	var prType PRType
-	switch {
-	case strings.HasPrefix(title, PrefixFeature):
-		title = strings.TrimPrefix(title, PrefixFeature)
-		prType = FeaturePR
-	case strings.HasPrefix(title, PrefixBugFix):
-		title = strings.TrimPrefix(title, PrefixBugFix)
-		prType = BugFixPR
-	case strings.HasPrefix(title, PrefixDocs):
-		title = strings.TrimPrefix(title, PrefixDocs)
-		prType = DocsPR
-	case strings.HasPrefix(title, PrefixInfra):
-		title = strings.TrimPrefix(title, PrefixInfra)
-		prType = InfraPR
-	case strings.HasPrefix(title, PrefixBreaking):
-		title = strings.TrimPrefix(title, PrefixBreaking)
-		prType = BreakingPR
-	case strings.HasPrefix(title, PrefixNoNote):
-		title = strings.TrimPrefix(title, PrefixNoNote)
-		prType = NoNotePR
-	case strings.HasPrefix(title, PrefixTestTube):
-		title = strings.TrimPrefix(title, PrefixTestTube)
-		prType = TestPR
-	default:
-		if strings.HasPrefix(title, emojiFeature) ||
-			strings.HasPrefix(title, emojiBugFix) ||
-			strings.HasPrefix(title, emojiDocs) ||
-			strings.HasPrefix(title, emojiInfra) ||
-			strings.HasPrefix(title, emojiBreaking) ||
-			strings.HasPrefix(title, emojiNoNote) ||
-			strings.HasPrefix(title, emojiTestTube) {
-			return UnknownPR, title, PRTypeUsedEmojiError{
-				PRTypeError: PRTypeError{title: title},
-				emojiUsed:   []rune(title)[0],
+	for _, t := range r.orDefault().byAlias {
+		if strings.HasPrefix(title, t.Alias) {
+			title = strings.TrimPrefix(title, t.Alias)
+			prType = PRType(t.Type)
+			break
+		}
+	}
+
+	if prType == UnknownPR {
+		if c, ok := r.parseConventional(title); ok {
+			if c.scope == "" {
+				c.scope = scope
+			}
+			if allowConventional {
+				return Title{Type: c.prType, Scope: c.scope, Title: c.subject, Conventional: true}, nil
+			}
+			if t, ok := r.Lookup(c.prType); ok {
+				return Title{Scope: scope, Title: title}, ConventionalCommitError{
+					PRTypeError: PRTypeError{title: title, registry: r},
+					suggestion:  c.rewrite(t.Alias),
+				}
+			}
+		}
+
+		for emoji := range r.orDefault().emojiAliasMap {
+			if strings.HasPrefix(title, emoji) {
+				return Title{Scope: scope, Title: title}, PRTypeUsedEmojiError{
+					PRTypeError: PRTypeError{title: title, registry: r},
+					emojiUsed:   []rune(title)[0],
+				}
 			}
 		}
-		return UnknownPR, title, PRTypeError{title: title}
+		return Title{Scope: scope, Title: title}, PRTypeError{title: title, registry: r}
 	}
 
 	// Trusting those that came before...
@@ -103,5 +168,5 @@ func TypeFromTitle(title string) (PRType, string, error) {
 	// (some systems sneak it in -- my guess is OSX)
 	title = strings.TrimPrefix(title, "\uFE0F")
 
-	return prType, strings.TrimSpace(title), nil
+	return Title{Type: prType, Scope: scope, Title: strings.TrimSpace(title)}, nil
 }
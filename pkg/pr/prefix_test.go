@@ -1,7 +1,10 @@
 package pr
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/konveyor/release-tools/pkg/config"
 )
 
 func TestTypeFromTitle(t *testing.T) {
@@ -115,3 +118,151 @@ func TestTypeFromTitle(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistryTypeFromTitle(t *testing.T) {
+	c := config.DefaultPRTypes()
+	c.Types = append(c.Types, config.PRTypeConfig{
+		Alias:       ":lock:",
+		Emoji:       "🔒",
+		Type:        "security",
+		Description: "Security fix",
+		Section:     "Security",
+		Impact:      config.ImpactPatch,
+	})
+	r := NewRegistry(c)
+
+	typ, title, err := r.TypeFromTitle(":lock: Bump vulnerable dependency")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if typ != PRType("security") {
+		t.Errorf("Expected PR type %q but got %q", "security", typ)
+	}
+	if title != "Bump vulnerable dependency" {
+		t.Errorf("Expected title %q but got %q", "Bump vulnerable dependency", title)
+	}
+
+	typ, _, err = r.TypeFromTitle(":bug: Still works")
+	if err != nil || typ != BugFixPR {
+		t.Errorf("Expected PR type %q but got %q (%v)", BugFixPR, typ, err)
+	}
+
+	_, _, err = r.TypeFromTitle("🔒 Emoji instead of alias")
+	if _, ok := err.(PRTypeUsedEmojiError); !ok {
+		t.Fatalf("Expected PRTypeUsedEmojiError but got %T", err)
+	}
+	if !strings.Contains(err.Error(), "`:lock:`") {
+		t.Errorf("Expected error to suggest the :lock: alias, got %q", err)
+	}
+
+	_, _, err = r.TypeFromTitle("No prefix")
+	if !strings.Contains(err.Error(), "- Security fix: (`:lock:`)") {
+		t.Errorf("Expected error to list the :lock: prefix, got %q", err)
+	}
+}
+
+func TestExtendedPRTypes(t *testing.T) {
+	c, err := config.LoadPRTypes("../config/pr-types-kai.yaml")
+	if err != nil {
+		t.Fatalf("LoadPRTypes() error = %v", err)
+	}
+	if len(c.Types) != len(config.DefaultPRTypes().Types)+2 {
+		t.Fatalf("Expected the default types and 2 more but got %d types", len(c.Types))
+	}
+	r := NewRegistry(c)
+
+	for title, want := range map[string]PRType{
+		":bug: Fix the table":      BugFixPR,
+		":rocket: Cache the rules": "perf",
+	} {
+		typ, _, err := r.TypeFromTitle(title)
+		if err != nil || typ != want {
+			t.Errorf("TypeFromTitle(%q) = %q, %v, want %q", title, typ, err, want)
+		}
+	}
+}
+
+func TestVerifyBaseBranch(t *testing.T) {
+	testCases := []struct {
+		prType        PRType
+		baseRef       string
+		labels        []string
+		expectedError error
+	}{
+		{prType: FeaturePR, baseRef: "main", expectedError: nil},
+		{prType: BugFixPR, baseRef: "release-0.8", expectedError: nil},
+		{prType: InfraPR, baseRef: "refs/heads/release-0.8", expectedError: nil},
+		{
+			prType:        FeaturePR,
+			baseRef:       "release-0.8",
+			expectedError: ReleaseBranchError{prType: FeaturePR, branch: "release-0.8", overrideLabel: ReleaseBranchOverrideLabel},
+		},
+		{
+			prType:        BreakingPR,
+			baseRef:       "refs/heads/release-0.8",
+			labels:        []string{"kind/feature"},
+			expectedError: ReleaseBranchError{prType: BreakingPR, branch: "release-0.8", overrideLabel: ReleaseBranchOverrideLabel},
+		},
+		{prType: FeaturePR, baseRef: "release-0.8", labels: []string{ReleaseBranchOverrideLabel}, expectedError: nil},
+		{prType: FeaturePR, baseRef: "release-notes-fix", expectedError: nil},
+		{prType: BreakingPR, baseRef: "refs/heads/release-0.8-hotfix", expectedError: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.prType)+"/"+tc.baseRef, func(t *testing.T) {
+			err := VerifyBaseBranch(tc.prType, tc.baseRef, tc.labels, ReleaseBranchOverrideLabel)
+			if err != tc.expectedError {
+				t.Errorf("Expected error %q but got %q", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestRegistryRules(t *testing.T) {
+	c := config.DefaultPRTypes()
+	c.Types = append(c.Types,
+		config.PRTypeConfig{Alias: ":lock:", Type: "security", Section: "Security", Impact: config.ImpactPatch},
+		config.PRTypeConfig{Alias: ":art:", Type: "ux", Section: "UX", Impact: config.ImpactMinor},
+		config.PRTypeConfig{Alias: ":memo:", Type: "chore", Impact: config.ImpactNone},
+	)
+	r := NewRegistry(c)
+
+	testCases := []struct {
+		prType        PRType
+		noteRequired  bool
+		noneAllowed   bool
+		issueRequired bool
+		branchError   bool
+	}{
+		{prType: "security", noteRequired: true, issueRequired: true},
+		{prType: "ux", noteRequired: true, issueRequired: true, branchError: true},
+		{prType: "chore", noneAllowed: true},
+		{prType: BreakingPR, noteRequired: true, branchError: true},
+		{prType: DocsPR, noneAllowed: true},
+		{prType: "unknown"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.prType), func(t *testing.T) {
+			if got := r.NoteRequired(tc.prType); got != tc.noteRequired {
+				t.Errorf("NoteRequired() = %v, want %v", got, tc.noteRequired)
+			}
+			if got := r.NoneNoteAllowed(tc.prType); got != tc.noneAllowed {
+				t.Errorf("NoneNoteAllowed() = %v, want %v", got, tc.noneAllowed)
+			}
+			if got := r.LinkedIssueRequired(tc.prType); got != tc.issueRequired {
+				t.Errorf("LinkedIssueRequired() = %v, want %v", got, tc.issueRequired)
+			}
+			err := r.VerifyBaseBranch(tc.prType, "release-0.8", nil, ReleaseBranchOverrideLabel)
+			if (err != nil) != tc.branchError {
+				t.Errorf("VerifyBaseBranch() error = %v, want error %v", err, tc.branchError)
+			}
+		})
+	}
+
+	// The types allowed to use NONE come from the registry
+	err := r.ValidateNote("security", NoteNone)
+	if want := "only allowed for `:book:`, `:seedling:`, `:test_tube:`, `:ghost:` or `:memo:` PRs"; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("ValidateNote() error = %v, want the registry types allowed to use NONE", err)
+	}
+}
@@ -0,0 +1,20 @@
+package prep
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diff renders the changes as a unified diff, one hunk per changed line
+func Diff(changes []Change) string {
+	var b strings.Builder
+	file := ""
+	for _, c := range changes {
+		if c.File != file {
+			file = c.File
+			fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", file, file)
+		}
+		fmt.Fprintf(&b, "@@ -%d +%d @@\n-%s\n+%s\n", c.Line, c.Line, c.Old, c.New)
+	}
+	return b.String()
+}
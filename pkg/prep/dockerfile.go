@@ -0,0 +1,107 @@
+package prep
+
+import (
+	"strings"
+)
+
+// Change is a line changed while preparing a release
+type Change struct {
+	File string `json:"file"`
+	// Line is the 1-based number of the changed line
+	Line int    `json:"line"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// RetagDockerfile retags the FROM references of the images to the tag. Only
+// the image reference is changed, flags like --platform and stage names like
+// "AS builder" are kept. Digests are dropped as they pin another tag.
+func RetagDockerfile(file, content string, images []string, tag string) (string, []Change) {
+	wanted := make(map[string]bool)
+	for _, image := range images {
+		name, _, _ := splitImage(image)
+		wanted[name] = true
+	}
+
+	lines := strings.Split(content, "\n")
+	var changes []Change
+	// inFrom is true while looking for the image of a FROM instruction
+	// continued on the next lines
+	inFrom, continued := false, false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") || (trimmed == "" && continued) {
+			continue
+		}
+
+		fields := fieldsWithOffsets(line)
+		if !continued {
+			inFrom = len(fields) > 0 && strings.EqualFold(fields[0].text, "FROM")
+			if inFrom {
+				fields = fields[1:]
+			}
+		}
+		continued = strings.HasSuffix(trimmed, "\\")
+
+		if !inFrom {
+			continue
+		}
+		for _, f := range fields {
+			if f.text == "\\" || strings.HasPrefix(f.text, "--") {
+				continue
+			}
+			// The first other token is the image
+			inFrom = false
+			name, _, _ := splitImage(f.text)
+			if !wanted[name] {
+				break
+			}
+			ref := name + ":" + tag
+			if ref == f.text {
+				break
+			}
+			changed := line[:f.offset] + ref + line[f.offset+len(f.text):]
+			changes = append(changes, Change{File: file, Line: i + 1, Old: line, New: changed})
+			lines[i] = changed
+			break
+		}
+	}
+	return strings.Join(lines, "\n"), changes
+}
+
+// splitImage splits an image reference into its name, tag and digest
+func splitImage(ref string) (name, tag, digest string) {
+	name, digest, _ = strings.Cut(ref, "@")
+	// The tag follows the last colon after the registry, whose port also
+	// follows a colon
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	return name, tag, digest
+}
+
+type field struct {
+	text   string
+	offset int
+}
+
+// fieldsWithOffsets splits a line on whitespace, keeping the offset of each
+// field in the line
+func fieldsWithOffsets(line string) []field {
+	var fields []field
+	start := -1
+	for i, r := range line {
+		space := r == ' ' || r == '\t' || r == '\r'
+		switch {
+		case space && start >= 0:
+			fields = append(fields, field{text: line[start:i], offset: start})
+			start = -1
+		case !space && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, field{text: line[start:], offset: start})
+	}
+	return fields
+}
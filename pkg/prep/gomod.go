@@ -0,0 +1,87 @@
+package prep
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// UpdateGoMod sets the version of the required modules, keeping the rest of
+// go.mod as is. go.sum is left to `go mod tidy`.
+func UpdateGoMod(file string, content []byte, versions map[string]string) ([]byte, []Change, error) {
+	f, err := modfile.ParseLax(file, content, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	var changes []Change
+	for _, r := range f.Require {
+		version, ok := versions[r.Mod.Path]
+		if !ok || version == r.Mod.Version {
+			continue
+		}
+		i := r.Syntax.Start.Line - 1
+		line := lines[i]
+		at := strings.Index(line, r.Mod.Path)
+		if at < 0 {
+			return nil, nil, fmt.Errorf("%s:%d: %s not found", file, i+1, r.Mod.Path)
+		}
+		at += len(r.Mod.Path)
+		changed := line[:at] + strings.Replace(line[at:], r.Mod.Version, version, 1)
+		changes = append(changes, Change{File: file, Line: i + 1, Old: line, New: changed})
+		lines[i] = changed
+	}
+	return []byte(strings.Join(lines, "\n")), changes, nil
+}
+
+// Resolver resolves the versions of Go modules with the go command
+type Resolver struct {
+	dir string
+}
+
+// NewResolver creates a new resolver running the go command in dir
+func NewResolver(dir string) *Resolver {
+	return &Resolver{dir: dir}
+}
+
+// BranchVersion returns the version `go get module@branch` resolves to: the
+// tag of the head of the branch, or a pseudo-version of it. The go command
+// computes it, so it fails rather than guess when the branch does not exist.
+func (r *Resolver) BranchVersion(ctx context.Context, modPath, branch string) (string, error) {
+	query := modPath + "@" + branch
+	cmd := exec.CommandContext(ctx, "go", "list", "-m", "-json", query)
+	cmd.Dir = r.dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("go list -m %s failed: %w: %s", query, err, strings.TrimSpace(stderr.String()))
+	}
+	return parseModuleVersion(stdout.Bytes())
+}
+
+// parseModuleVersion returns the version of the module printed by
+// `go list -m -json`
+func parseModuleVersion(out []byte) (string, error) {
+	var m struct {
+		Path    string
+		Version string
+		Error   *struct{ Err string }
+	}
+	if err := json.Unmarshal(out, &m); err != nil {
+		return "", fmt.Errorf("failed to parse go list output: %w", err)
+	}
+	if m.Error != nil {
+		return "", fmt.Errorf("%s: %s", m.Path, m.Error.Err)
+	}
+	if m.Version == "" {
+		return "", fmt.Errorf("%s has no version", m.Path)
+	}
+	return m.Version, nil
+}
@@ -0,0 +1,141 @@
+package prep
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRetagDockerfile(t *testing.T) {
+	dockerfile := `ARG BASE=registry.access.redhat.com/ubi9/ubi-minimal
+FROM quay.io/konveyor/analyzer-lsp:latest AS analyzer
+FROM --platform=$BUILDPLATFORM quay.io/konveyor/java-external-provider@sha256:0123 as java
+from quay.io/konveyor/analyzer-lsp-extra:latest
+FROM golang:1.21 AS builder
+COPY --from=quay.io/konveyor/analyzer-lsp:latest /usr/bin/konveyor-analyzer /usr/bin
+# FROM quay.io/konveyor/analyzer-lsp:latest
+FROM --platform=linux/amd64 \
+    quay.io/konveyor/dotnet-external-provider:v0.7.0 AS dotnet
+FROM builder AS final
+`
+	images := []string{
+		"quay.io/konveyor/analyzer-lsp",
+		"quay.io/konveyor/java-external-provider:latest",
+		"quay.io/konveyor/dotnet-external-provider",
+		"golang",
+	}
+
+	got, changes := RetagDockerfile("Dockerfile", dockerfile, images, "release-0.8")
+
+	want := `ARG BASE=registry.access.redhat.com/ubi9/ubi-minimal
+FROM quay.io/konveyor/analyzer-lsp:release-0.8 AS analyzer
+FROM --platform=$BUILDPLATFORM quay.io/konveyor/java-external-provider:release-0.8 as java
+from quay.io/konveyor/analyzer-lsp-extra:latest
+FROM golang:release-0.8 AS builder
+COPY --from=quay.io/konveyor/analyzer-lsp:latest /usr/bin/konveyor-analyzer /usr/bin
+# FROM quay.io/konveyor/analyzer-lsp:latest
+FROM --platform=linux/amd64 \
+    quay.io/konveyor/dotnet-external-provider:release-0.8 AS dotnet
+FROM builder AS final
+`
+	if got != want {
+		t.Errorf("RetagDockerfile() =\n%s\nwant\n%s", got, want)
+	}
+
+	var lines []int
+	for _, c := range changes {
+		lines = append(lines, c.Line)
+	}
+	if len(lines) != 4 || lines[0] != 2 || lines[1] != 3 || lines[2] != 5 || lines[3] != 9 {
+		t.Errorf("expected changes on lines 2, 3, 5 and 9, got %v", lines)
+	}
+
+	if _, changes := RetagDockerfile("Dockerfile", want, images, "release-0.8"); len(changes) != 0 {
+		t.Errorf("expected retagging twice to be a no-op, got %v", changes)
+	}
+}
+
+func TestSplitImage(t *testing.T) {
+	tests := []struct {
+		ref               string
+		name, tag, digest string
+	}{
+		{ref: "golang", name: "golang"},
+		{ref: "golang:1.21", name: "golang", tag: "1.21"},
+		{ref: "localhost:5000/konveyor/hub", name: "localhost:5000/konveyor/hub"},
+		{ref: "localhost:5000/konveyor/hub:v0.8.0", name: "localhost:5000/konveyor/hub", tag: "v0.8.0"},
+		{ref: "quay.io/konveyor/hub:latest@sha256:0123", name: "quay.io/konveyor/hub", tag: "latest", digest: "sha256:0123"},
+	}
+	for _, tt := range tests {
+		name, tag, digest := splitImage(tt.ref)
+		if name != tt.name || tag != tt.tag || digest != tt.digest {
+			t.Errorf("splitImage(%q) = %q, %q, %q, want %q, %q, %q", tt.ref, name, tag, digest, tt.name, tt.tag, tt.digest)
+		}
+	}
+}
+
+func TestUpdateGoMod(t *testing.T) {
+	gomod := `module github.com/konveyor/kantra
+
+go 1.21
+
+require github.com/konveyor/analyzer-lsp v0.8.0-alpha.1
+
+require (
+	github.com/konveyor/analyzer-lsp/external-providers/java-external-provider v0.0.0-20240101000000-0123456789ab // indirect
+	github.com/sirupsen/logrus v1.9.3
+)
+`
+	got, changes, err := UpdateGoMod("go.mod", []byte(gomod), map[string]string{
+		"github.com/konveyor/analyzer-lsp":                                           "v0.8.0-alpha.1.0.20240301000000-abcdefabcdef",
+		"github.com/konveyor/analyzer-lsp/external-providers/java-external-provider": "v0.0.0-20240301000000-abcdefabcdef",
+		"github.com/konveyor/tackle2-hub":                                            "v0.8.0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `module github.com/konveyor/kantra
+
+go 1.21
+
+require github.com/konveyor/analyzer-lsp v0.8.0-alpha.1.0.20240301000000-abcdefabcdef
+
+require (
+	github.com/konveyor/analyzer-lsp/external-providers/java-external-provider v0.0.0-20240301000000-abcdefabcdef // indirect
+	github.com/sirupsen/logrus v1.9.3
+)
+`
+	if string(got) != want {
+		t.Errorf("UpdateGoMod() =\n%s\nwant\n%s", got, want)
+	}
+
+	diff := Diff(changes)
+	wantDiff := "--- a/go.mod\n+++ b/go.mod\n@@ -5 +5 @@\n-require github.com/konveyor/analyzer-lsp v0.8.0-alpha.1\n+require github.com/konveyor/analyzer-lsp v0.8.0-alpha.1.0.20240301000000-abcdefabcdef\n"
+	if !strings.HasPrefix(diff, wantDiff) {
+		t.Errorf("Diff() =\n%s\nwant prefix\n%s", diff, wantDiff)
+	}
+}
+
+func TestParseModuleVersion(t *testing.T) {
+	tests := []struct {
+		out     string
+		want    string
+		wantErr bool
+	}{
+		{out: `{"Path": "github.com/konveyor/analyzer-lsp", "Version": "v0.8.0-alpha.1.0.20240301000000-abcdefabcdef", "Time": "2024-03-01T00:00:00Z"}`, want: "v0.8.0-alpha.1.0.20240301000000-abcdefabcdef"},
+		{out: `{"Path": "github.com/konveyor/analyzer-lsp", "Version": "v0.8.0"}`, want: "v0.8.0"},
+		{out: `{"Path": "github.com/konveyor/analyzer-lsp", "Error": {"Err": "unknown revision release-0.9"}}`, wantErr: true},
+		{out: `{"Path": "github.com/konveyor/analyzer-lsp"}`, wantErr: true},
+		{out: `not json`, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseModuleVersion([]byte(tt.out))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseModuleVersion(%s) error = %v, wantErr %v", tt.out, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseModuleVersion(%s) = %q, want %q", tt.out, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/config"
+	"github.com/konveyor/release-tools/pkg/pr"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultBlockerLabels are the labels of the issues and PRs blocking a release
+var DefaultBlockerLabels = []string{"priority/release-blocker", "build-blocker"}
+
+// Matches the X.Y of milestone titles like "v0.3.0" or "0.3-beta.2"
+var milestoneVersionRegex = regexp.MustCompile(`^v?(\d+)\.(\d+)`)
+
+// Checker checks the readiness of repos for a release
+type Checker struct {
+	client        *github.Client
+	blockerLabels []string
+}
+
+// NewChecker creates a new checker with the given GitHub client, using the
+// default blocker labels when none are given
+func NewChecker(client *github.Client, blockerLabels []string) *Checker {
+	if len(blockerLabels) == 0 {
+		blockerLabels = DefaultBlockerLabels
+	}
+	return &Checker{client: client, blockerLabels: blockerLabels}
+}
+
+// ReleaseBranch returns the release-X.Y branch of a milestone title, false
+// when the title is not a version
+func ReleaseBranch(milestone string) (string, bool) {
+	m := milestoneVersionRegex.FindStringSubmatch(milestone)
+	if m == nil {
+		return "", false
+	}
+	return fmt.Sprintf("release-%s.%s", m[1], m[2]), true
+}
+
+// Check reports the readiness of every repo for the milestone. Checks that
+// fail are recorded in the repo report rather than stopping the report.
+func (c *Checker) Check(ctx context.Context, repos []config.Repo, milestone string) *Report {
+	report := &Report{Milestone: milestone}
+	report.ReleaseBranch, _ = ReleaseBranch(milestone)
+
+	for _, repo := range repos {
+		logrus.Infof("Checking %s/%s", repo.Org, repo.Repo)
+		report.Repos = append(report.Repos, c.checkRepo(ctx, repo.Org, repo.Repo, milestone, report.ReleaseBranch))
+	}
+	return report
+}
+
+func (c *Checker) checkRepo(ctx context.Context, org, repo, milestone, releaseBranch string) RepoReport {
+	r := RepoReport{
+		Org:              org,
+		Repo:             repo,
+		OpenIssues:       []Item{},
+		OpenPRs:          []Item{},
+		Blockers:         []Item{},
+		CI:               []BranchStatus{},
+		PendingBackports: []Item{},
+	}
+	fail := func(format string, err error) {
+		logrus.WithError(err).Warnf("%s/%s: "+format, org, repo)
+		r.Errors = append(r.Errors, fmt.Sprintf(format+": %v", err))
+	}
+
+	number, err := c.milestoneNumber(ctx, org, repo, milestone)
+	switch {
+	case err != nil:
+		fail("failed to find the milestone", err)
+	case number == 0:
+		r.MissingMilestone = true
+	default:
+		issues, err := c.listOpen(ctx, org, repo, &github.IssueListByRepoOptions{Milestone: fmt.Sprint(number)})
+		if err != nil {
+			fail("failed to list the milestone issues", err)
+		}
+		for _, issue := range issues {
+			if issue.IsPullRequest() {
+				r.OpenPRs = append(r.OpenPRs, newItem(issue))
+			} else {
+				r.OpenIssues = append(r.OpenIssues, newItem(issue))
+			}
+		}
+	}
+
+	seen := make(map[int]bool)
+	for _, label := range c.blockerLabels {
+		issues, err := c.listOpen(ctx, org, repo, &github.IssueListByRepoOptions{Labels: []string{label}})
+		if err != nil {
+			fail(fmt.Sprintf("failed to list %s issues", label), err)
+			continue
+		}
+		for _, issue := range issues {
+			if !seen[issue.GetNumber()] {
+				seen[issue.GetNumber()] = true
+				r.Blockers = append(r.Blockers, newItem(issue))
+			}
+		}
+	}
+	sort.Slice(r.Blockers, func(i, j int) bool { return r.Blockers[i].Number < r.Blockers[j].Number })
+
+	repository, _, err := c.client.Repositories.Get(ctx, org, repo)
+	if err != nil {
+		fail("failed to get the repo", err)
+	} else {
+		c.addCI(ctx, &r, repository.GetDefaultBranch(), fail)
+	}
+
+	if releaseBranch == "" {
+		return r
+	}
+	if !c.addCI(ctx, &r, releaseBranch, fail) {
+		// The release branch is not cut yet, there is nothing to backport
+		return r
+	}
+	backports, err := c.pendingBackports(ctx, org, repo, releaseBranch)
+	if err != nil {
+		fail("failed to list the pending backports", err)
+	}
+	r.PendingBackports = append(r.PendingBackports, backports...)
+	return r
+}
+
+// addCI adds the CI state of the branch, returning false when the branch does
+// not exist
+func (c *Checker) addCI(ctx context.Context, r *RepoReport, branch string, fail func(string, error)) bool {
+	status, err := c.BranchStatus(ctx, r.Org, r.Repo, branch)
+	if err != nil {
+		fail(fmt.Sprintf("failed to get the CI state of %s", branch), err)
+		return true
+	}
+	if status == nil {
+		logrus.Debugf("%s/%s has no %s branch", r.Org, r.Repo, branch)
+		return false
+	}
+	r.CI = append(r.CI, *status)
+	return true
+}
+
+// milestoneNumber returns the number of the milestone of the title, 0 when
+// the repo has none
+func (c *Checker) milestoneNumber(ctx context.Context, org, repo, title string) (int, error) {
+	opts := &github.MilestoneListOptions{State: "all", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		milestones, resp, err := c.client.Issues.ListMilestones(ctx, org, repo, opts)
+		if err != nil {
+			return 0, err
+		}
+		for _, m := range milestones {
+			if m.GetTitle() == title {
+				return m.GetNumber(), nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return 0, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// listOpen lists the open issues and PRs matching the options
+func (c *Checker) listOpen(ctx context.Context, org, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, error) {
+	opts.State = "open"
+	opts.ListOptions = github.ListOptions{PerPage: 100}
+
+	var all []*github.Issue
+	for {
+		issues, resp, err := c.client.Issues.ListByRepo(ctx, org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, issues...)
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// BranchStatus returns the CI state of the head of the branch from its
+// statuses and check runs, nil when the branch does not exist
+func (c *Checker) BranchStatus(ctx context.Context, org, repo, branch string) (*BranchStatus, error) {
+	b, resp, err := c.client.Repositories.GetBranch(ctx, org, repo, branch, true)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	sha := b.GetCommit().GetSHA()
+
+	var states []string
+	var failed []string
+	combined, _, err := c.client.Repositories.GetCombinedStatus(ctx, org, repo, sha, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the combined status: %w", err)
+	}
+	for _, s := range combined.Statuses {
+		state := statusState(s.GetState())
+		states = append(states, state)
+		if state == CIFailure {
+			failed = append(failed, s.GetContext())
+		}
+	}
+
+	opts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		runs, resp, err := c.client.Checks.ListCheckRunsForRef(ctx, org, repo, sha, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the check runs: %w", err)
+		}
+		for _, run := range runs.CheckRuns {
+			state := checkRunState(run.GetStatus(), run.GetConclusion())
+			states = append(states, state)
+			if state == CIFailure {
+				failed = append(failed, run.GetName())
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return &BranchStatus{Branch: branch, SHA: sha, State: combineStates(states), Failed: failed}, nil
+}
+
+// pendingBackports returns the open PRs to the release branch backporting a
+// PR
+func (c *Checker) pendingBackports(ctx context.Context, org, repo, branch string) ([]Item, error) {
+	opts := &github.PullRequestListOptions{State: "open", Base: branch, ListOptions: github.ListOptions{PerPage: 100}}
+	var items []Item
+	for {
+		pulls, resp, err := c.client.PullRequests.List(ctx, org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, pull := range pulls {
+			if _, ok := pr.BackportOf(pull.GetHead().GetRef(), pull.GetBody()); !ok {
+				continue
+			}
+			item := Item{Number: pull.GetNumber(), Title: pull.GetTitle(), URL: pull.GetHTMLURL()}
+			for _, label := range pull.Labels {
+				item.Labels = append(item.Labels, label.GetName())
+			}
+			items = append(items, item)
+		}
+		if resp.NextPage == 0 {
+			return items, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func newItem(issue *github.Issue) Item {
+	item := Item{Number: issue.GetNumber(), Title: issue.GetTitle(), URL: issue.GetHTMLURL()}
+	for _, label := range issue.Labels {
+		item.Labels = append(item.Labels, label.GetName())
+	}
+	return item
+}
+
+// statusState maps a commit status state to a CI state
+func statusState(state string) string {
+	switch state {
+	case "success":
+		return CISuccess
+	case "pending":
+		return CIPending
+	}
+	return CIFailure
+}
+
+// checkRunState maps the status and conclusion of a check run to a CI state
+func checkRunState(status, conclusion string) string {
+	if status != "completed" {
+		return CIPending
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return CISuccess
+	}
+	return CIFailure
+}
+
+// combineStates returns the state of a branch from the state of its statuses
+// and check runs, the worst one winning
+func combineStates(states []string) string {
+	if len(states) == 0 {
+		return CINone
+	}
+	state := CISuccess
+	for _, s := range states {
+		switch s {
+		case CIFailure:
+			return CIFailure
+		case CIPending:
+			state = CIPending
+		}
+	}
+	return state
+}
@@ -0,0 +1,97 @@
+package readiness
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konveyor/release-tools/pkg/action"
+)
+
+// Render renders the report in the format
+func Render(r *Report, format string) (string, error) {
+	switch format {
+	case action.FormatMarkdown, "md":
+		return RenderMarkdown(r), nil
+	case action.FormatJSON:
+		data, err := action.MarshalJSON(r)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal the readiness report: %w", err)
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("unknown readiness report format %q, expected %s or %s", format, action.FormatMarkdown, action.FormatJSON)
+}
+
+// RenderMarkdown renders the report as a go/no-go summary followed by the
+// details of the repos that are not ready
+func RenderMarkdown(r *Report) string {
+	var b strings.Builder
+
+	verdict := ":white_check_mark: GO"
+	switch {
+	case r.Blocked():
+		verdict = ":no_entry: NO-GO, blocked"
+	case !r.Ready():
+		verdict = ":warning: NO-GO"
+	}
+	fmt.Fprintf(&b, "# Release readiness of %s: %s\n\n", r.Milestone, verdict)
+
+	b.WriteString("| Repository | Status | Reasons |\n")
+	b.WriteString("|---|---|---|\n")
+	for _, repo := range r.Repos {
+		status := ":white_check_mark:"
+		switch {
+		case repo.Blocked():
+			status = ":no_entry:"
+		case !repo.Ready():
+			status = ":warning:"
+		}
+		reasons := strings.Join(repo.Reasons(), ", ")
+		if repo.MissingMilestone {
+			if reasons != "" {
+				reasons += ", "
+			}
+			reasons += "no milestone"
+		}
+		fmt.Fprintf(&b, "| %s/%s | %s | %s |\n", repo.Org, repo.Repo, status, reasons)
+	}
+
+	for _, repo := range r.Repos {
+		if repo.Ready() {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s/%s\n", repo.Org, repo.Repo)
+		writeItems(&b, "Blockers", repo.Blockers)
+		writeItems(&b, "Open issues", repo.OpenIssues)
+		writeItems(&b, "Open PRs", repo.OpenPRs)
+		for _, ci := range repo.CI {
+			if ci.State == CISuccess || ci.State == CINone {
+				continue
+			}
+			fmt.Fprintf(&b, "\n**CI of %s is %s**", ci.Branch, ci.State)
+			if len(ci.Failed) > 0 {
+				fmt.Fprintf(&b, ": %s", strings.Join(ci.Failed, ", "))
+			}
+			b.WriteString("\n")
+		}
+		writeItems(&b, "Pending backports", repo.PendingBackports)
+		if len(repo.Errors) > 0 {
+			b.WriteString("\n### Failed checks\n")
+			for _, err := range repo.Errors {
+				fmt.Fprintf(&b, "* %s\n", err)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func writeItems(b *strings.Builder, title string, items []Item) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n### %s\n", title)
+	for _, item := range items {
+		fmt.Fprintf(b, "* %s in %s\n", item.Title, item.URL)
+	}
+}
@@ -0,0 +1,106 @@
+package readiness
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReleaseBranch(t *testing.T) {
+	tests := []struct {
+		milestone string
+		want      string
+		wantOK    bool
+	}{
+		{milestone: "v0.3.0", want: "release-0.3", wantOK: true},
+		{milestone: "v0.3.1", want: "release-0.3", wantOK: true},
+		{milestone: "0.3-beta.2", want: "release-0.3", wantOK: true},
+		{milestone: "v1.10.0-alpha.1", want: "release-1.10", wantOK: true},
+		{milestone: "Backlog", wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := ReleaseBranch(tt.milestone)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ReleaseBranch(%q) = %q, %v, want %q, %v", tt.milestone, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestCombineStates(t *testing.T) {
+	tests := []struct {
+		states []string
+		want   string
+	}{
+		{states: nil, want: CINone},
+		{states: []string{CISuccess, CISuccess}, want: CISuccess},
+		{states: []string{CISuccess, CIPending}, want: CIPending},
+		{states: []string{CIPending, CIFailure, CISuccess}, want: CIFailure},
+		{states: []string{checkRunState("completed", "skipped"), statusState("success")}, want: CISuccess},
+		{states: []string{checkRunState("in_progress", "")}, want: CIPending},
+		{states: []string{checkRunState("completed", "timed_out")}, want: CIFailure},
+		{states: []string{statusState("error")}, want: CIFailure},
+	}
+	for _, tt := range tests {
+		if got := combineStates(tt.states); got != tt.want {
+			t.Errorf("combineStates(%v) = %q, want %q", tt.states, got, tt.want)
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	ready := RepoReport{
+		Org:  "konveyor",
+		Repo: "operator",
+		CI:   []BranchStatus{{Branch: "main", State: CISuccess}, {Branch: "release-0.3", State: CINone}},
+	}
+	pending := RepoReport{
+		Org:              "konveyor",
+		Repo:             "analyzer-lsp",
+		OpenIssues:       []Item{{Number: 1, Title: "Crash on start", URL: "https://github.com/konveyor/analyzer-lsp/issues/1"}},
+		CI:               []BranchStatus{{Branch: "release-0.3", State: CIFailure, Failed: []string{"e2e"}}},
+		PendingBackports: []Item{{Number: 3, Title: ":bug: Fix crash (#2)", URL: "https://github.com/konveyor/analyzer-lsp/pull/3"}},
+	}
+	blocked := RepoReport{
+		Org:      "konveyor",
+		Repo:     "tackle2-hub",
+		Blockers: []Item{{Number: 7, Title: "Data loss", URL: "https://github.com/konveyor/tackle2-hub/issues/7"}},
+	}
+
+	if !ready.Ready() || ready.Blocked() {
+		t.Errorf("expected %s to be ready, got reasons %v", ready.Repo, ready.Reasons())
+	}
+	wantReasons := []string{"1 open issue", "release-0.3 CI failure", "1 pending backport"}
+	if got := pending.Reasons(); strings.Join(got, ", ") != strings.Join(wantReasons, ", ") {
+		t.Errorf("Reasons() = %v, want %v", got, wantReasons)
+	}
+	if pending.Blocked() {
+		t.Errorf("expected %s not to be blocked", pending.Repo)
+	}
+
+	r := &Report{Milestone: "v0.3.0", Repos: []RepoReport{ready, pending}}
+	if r.Ready() || r.Blocked() {
+		t.Errorf("expected a no-go without blockers")
+	}
+	md := RenderMarkdown(r)
+	for _, want := range []string{
+		"# Release readiness of v0.3.0: :warning: NO-GO",
+		"| konveyor/operator | :white_check_mark: |  |",
+		"## konveyor/analyzer-lsp",
+		"**CI of release-0.3 is failure**: e2e",
+		"* :bug: Fix crash (#2) in https://github.com/konveyor/analyzer-lsp/pull/3",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "## konveyor/operator") {
+		t.Errorf("expected no details for a ready repo, got:\n%s", md)
+	}
+
+	r.Repos = append(r.Repos, blocked)
+	if !r.Blocked() {
+		t.Errorf("expected the report to be blocked")
+	}
+	if md := RenderMarkdown(r); !strings.Contains(md, "NO-GO, blocked") {
+		t.Errorf("expected a blocked verdict, got:\n%s", md)
+	}
+}
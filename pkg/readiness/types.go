@@ -0,0 +1,120 @@
+package readiness
+
+import "fmt"
+
+// CI states of a branch
+const (
+	CISuccess = "success"
+	CIFailure = "failure"
+	CIPending = "pending"
+	// CINone is the state of a branch without any status or check run
+	CINone = "none"
+)
+
+// Report is the readiness of every configured repo for a milestone
+type Report struct {
+	Milestone string `json:"milestone"`
+	// ReleaseBranch is the release-X.Y branch of the milestone, empty when
+	// the milestone title is not a version
+	ReleaseBranch string       `json:"release_branch,omitempty"`
+	Repos         []RepoReport `json:"repos"`
+}
+
+// RepoReport is the readiness of a repo for the milestone
+type RepoReport struct {
+	Org  string `json:"org"`
+	Repo string `json:"repo"`
+	// MissingMilestone is true when the repo has no milestone of the title
+	MissingMilestone bool   `json:"missing_milestone,omitempty"`
+	OpenIssues       []Item `json:"open_issues"`
+	OpenPRs          []Item `json:"open_prs"`
+	// Blockers are the open issues and PRs with a blocker label, in or out
+	// of the milestone
+	Blockers []Item         `json:"blockers"`
+	CI       []BranchStatus `json:"ci"`
+	// PendingBackports are the open cherry-pick PRs to the release branch
+	PendingBackports []Item `json:"pending_backports"`
+	// Errors are the checks that could not be made
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Item is an issue or a PR
+type Item struct {
+	Number int      `json:"number"`
+	Title  string   `json:"title"`
+	URL    string   `json:"url"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// BranchStatus is the CI state of the head of a branch
+type BranchStatus struct {
+	Branch string `json:"branch"`
+	SHA    string `json:"sha"`
+	State  string `json:"state"`
+	// Failed are the names of the failed statuses and check runs
+	Failed []string `json:"failed,omitempty"`
+}
+
+// Blocked returns true when a repo has blockers
+func (r *Report) Blocked() bool {
+	for _, repo := range r.Repos {
+		if repo.Blocked() {
+			return true
+		}
+	}
+	return false
+}
+
+// Ready returns true when every repo is ready, the go of a go/no-go
+func (r *Report) Ready() bool {
+	for _, repo := range r.Repos {
+		if !repo.Ready() {
+			return false
+		}
+	}
+	return true
+}
+
+// Blocked returns true when the repo has open issues or PRs with a blocker
+// label
+func (r *RepoReport) Blocked() bool {
+	return len(r.Blockers) > 0
+}
+
+// Ready returns true when nothing is left to do before tagging the repo
+func (r *RepoReport) Ready() bool {
+	return len(r.Reasons()) == 0
+}
+
+// Reasons explains why the repo is not ready
+func (r *RepoReport) Reasons() []string {
+	var reasons []string
+	if n := len(r.Blockers); n > 0 {
+		reasons = append(reasons, plural(n, "blocker"))
+	}
+	if n := len(r.OpenIssues); n > 0 {
+		reasons = append(reasons, plural(n, "open issue"))
+	}
+	if n := len(r.OpenPRs); n > 0 {
+		reasons = append(reasons, plural(n, "open PR"))
+	}
+	for _, ci := range r.CI {
+		if ci.State != CISuccess && ci.State != CINone {
+			reasons = append(reasons, ci.Branch+" CI "+ci.State)
+		}
+	}
+	if n := len(r.PendingBackports); n > 0 {
+		reasons = append(reasons, plural(n, "pending backport"))
+	}
+	if len(r.Errors) > 0 {
+		reasons = append(reasons, plural(len(r.Errors), "failed check"))
+	}
+	return reasons
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
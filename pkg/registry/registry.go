@@ -0,0 +1,225 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DefaultHost is the registry of images without a registry host, e.g.
+// "konveyor/tackle2-hub"
+const DefaultHost = "registry-1.docker.io"
+
+// manifestTypes are the manifests a tag may point at, multi-arch images are
+// pushed as an index or a manifest list
+var manifestTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// Options of a registry client
+type Options struct {
+	// Host replaces the registry host of every image, e.g. localhost:5000 to
+	// check a local registry
+	Host string
+	// PlainHTTP talks to the registry over http instead of https
+	PlainHTTP bool
+	// Username and Password authenticate to the registry, images are pulled
+	// anonymously without them
+	Username string
+	Password string
+}
+
+// Client checks image tags with the OCI distribution API
+type Client struct {
+	http *http.Client
+	opts Options
+}
+
+// NewClient creates a new registry client
+func NewClient(opts Options) *Client {
+	return &Client{http: http.DefaultClient, opts: opts}
+}
+
+// Reference is an image without a tag, e.g. quay.io/konveyor/tackle2-hub
+type Reference struct {
+	Host       string
+	Repository string
+}
+
+func (r Reference) String() string {
+	return r.Host + "/" + r.Repository
+}
+
+// ParseReference parses an image without a tag or digest. Like docker, the
+// first component is a registry host only when it looks like one.
+func ParseReference(image string) (Reference, error) {
+	if image == "" || strings.ContainsAny(image, "@ ") {
+		return Reference{}, fmt.Errorf("invalid image %q", image)
+	}
+	host, repo := DefaultHost, image
+	if i := strings.Index(image, "/"); i > 0 {
+		first := image[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			host, repo = first, image[i+1:]
+		}
+	}
+	if repo == "" || strings.Contains(repo[strings.LastIndex(repo, "/")+1:], ":") {
+		return Reference{}, fmt.Errorf("invalid image %q, expected no tag", image)
+	}
+	if host == DefaultHost && !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+	return Reference{Host: host, Repository: repo}, nil
+}
+
+// TagExists returns whether the tag of the image exists in the registry
+func (c *Client) TagExists(ctx context.Context, image, tag string) (bool, error) {
+	resp, err := c.manifest(ctx, image, tag)
+	if err != nil {
+		return false, err
+	}
+	return resp != nil, nil
+}
+
+// Digest returns the digest of the manifest the tag of the image points at,
+// the index of a multi-arch image. It returns an empty digest when the tag
+// does not exist.
+func (c *Client) Digest(ctx context.Context, image, tag string) (string, error) {
+	resp, err := c.manifest(ctx, image, tag)
+	if err != nil || resp == nil {
+		return "", err
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("registry returned no digest for %s:%s", image, tag)
+	}
+	return digest, nil
+}
+
+// manifest gets the manifest of the tag of the image, it returns a nil
+// response when the tag does not exist
+func (c *Client) manifest(ctx context.Context, image, tag string) (*http.Response, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.Host != "" {
+		ref.Host = c.opts.Host
+	}
+	scheme := "https"
+	if c.opts.PlainHTTP {
+		scheme = "http"
+	}
+	manifest := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", scheme, ref.Host, ref.Repository, url.PathEscape(tag))
+
+	resp, err := c.head(ctx, manifest, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		auth, err := c.authorize(ctx, resp.Header.Get("Www-Authenticate"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to authenticate to %s: %w", ref.Host, err)
+		}
+		if resp, err = c.head(ctx, manifest, auth); err != nil {
+			return nil, err
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusNotFound:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unexpected status %s for %s:%s", resp.Status, ref, tag)
+}
+
+func (c *Client) head(ctx context.Context, manifest, auth string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifest, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestTypes, ", "))
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest %s: %w", manifest, err)
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// authorize answers the challenge of the registry with the Authorization
+// header to retry with
+func (c *Client) authorize(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.opts.Username == "" {
+			return "", fmt.Errorf("registry requires credentials")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.opts.Username+":"+c.opts.Password)), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("unsupported challenge %q", challenge)
+	}
+
+	values := map[string]string{}
+	for _, m := range challengeParam.FindAllStringSubmatch(params, -1) {
+		values[m[1]] = m[2]
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || values["realm"] == "" {
+		return "", fmt.Errorf("invalid realm in challenge %q", challenge)
+	}
+	query := realm.Query()
+	for _, k := range []string{"service", "scope"} {
+		if values[k] != "" {
+			query.Set(k, values[k])
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.opts.Username != "" {
+		req.SetBasicAuth(c.opts.Username, c.opts.Password)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get token: %s", resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode token: %w", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("registry returned no token")
+	}
+	return "Bearer " + token.Token, nil
+}
@@ -0,0 +1,87 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		image   string
+		want    Reference
+		wantErr bool
+	}{
+		{image: "quay.io/konveyor/tackle2-hub", want: Reference{Host: "quay.io", Repository: "konveyor/tackle2-hub"}},
+		{image: "localhost:5000/konveyor/tackle2-ui", want: Reference{Host: "localhost:5000", Repository: "konveyor/tackle2-ui"}},
+		{image: "konveyor/kantra", want: Reference{Host: DefaultHost, Repository: "konveyor/kantra"}},
+		{image: "golang", want: Reference{Host: DefaultHost, Repository: "library/golang"}},
+		{image: "quay.io/konveyor/tackle2-hub:v0.8.0", wantErr: true},
+		{image: "quay.io/konveyor/tackle2-hub@sha256:abc", wantErr: true},
+		{image: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			got, err := ParseReference(tt.image)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseReference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagExists(t *testing.T) {
+	const token = "anonymous-token"
+	const digest = "sha256:0123456789abcdef"
+
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("scope") != "repository:konveyor/tackle2-hub:pull" {
+			http.Error(w, "bad scope", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"token": %q}`, token)
+	})
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead || !strings.Contains(r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json") {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.Header().Set("Www-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:konveyor/tackle2-hub:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/v2/konveyor/tackle2-hub/manifests/v0.8.0" {
+			w.Header().Set("Docker-Content-Digest", digest)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.NotFound(w, r)
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(Options{Host: strings.TrimPrefix(server.URL, "http://"), PlainHTTP: true})
+	for tag, want := range map[string]bool{"v0.8.0": true, "v0.9.0": false} {
+		got, err := client.TagExists(context.Background(), "quay.io/konveyor/tackle2-hub", tag)
+		if err != nil {
+			t.Fatalf("TagExists(%s) error = %v", tag, err)
+		}
+		if got != want {
+			t.Errorf("TagExists(%s) = %t, want %t", tag, got, want)
+		}
+	}
+
+	got, err := client.Digest(context.Background(), "quay.io/konveyor/tackle2-hub", "v0.8.0")
+	if err != nil || got != digest {
+		t.Errorf("Digest() = %q, %v, want %q", got, err, digest)
+	}
+}
@@ -0,0 +1,109 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// ActionDiverged is the action of a branch plan when the branch already
+// exists on another commit, it is never changed
+const ActionDiverged = "diverged"
+
+// BranchPlan is what is needed to cut a release branch in a repo
+type BranchPlan struct {
+	Org    string `json:"org"`
+	Repo   string `json:"repo"`
+	Branch string `json:"branch"`
+	// SHA is the commit the branch is cut from
+	SHA    string `json:"sha"`
+	Action string `json:"action"`
+	// Current is the commit of the existing branch
+	Current string `json:"current,omitempty"`
+	Why     string `json:"why,omitempty"`
+}
+
+// PlanBranch plans cutting the branch from the ref, or from the head of the
+// default branch when ref is empty. An existing branch is left unchanged when
+// it is at the commit, or behind the default branch that moved on since it
+// was cut, and reported as diverged otherwise.
+func (r *Releaser) PlanBranch(ctx context.Context, org, repo, branch, ref string) (*BranchPlan, error) {
+	plan := &BranchPlan{Org: org, Repo: repo, Branch: branch}
+
+	defaultBranch := ref == ""
+	if defaultBranch {
+		repository, _, err := r.client.Repositories.Get(ctx, org, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repo: %w", err)
+		}
+		ref = repository.GetDefaultBranch()
+	}
+	sha, _, err := r.client.Repositories.GetCommitSHA1(ctx, org, repo, ref, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	plan.SHA = sha
+
+	existing, resp, err := r.client.Git.GetRef(ctx, org, repo, "heads/"+branch)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			plan.Action = ActionCreate
+			plan.Why = "missing"
+			return plan, nil
+		}
+		return nil, fmt.Errorf("failed to get branch %s: %w", branch, err)
+	}
+
+	plan.Current = existing.GetObject().GetSHA()
+	if plan.Current == sha {
+		plan.Action = ActionUnchanged
+		plan.Why = "already cut at " + shortSHA(sha)
+		return plan, nil
+	}
+
+	comparison, _, err := r.client.Repositories.CompareCommits(ctx, org, repo, sha, plan.Current, &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s with %s: %w", branch, ref, err)
+	}
+	if status := comparison.GetStatus(); status == "identical" || (status == "behind" && defaultBranch) {
+		plan.Action = ActionUnchanged
+		plan.Why = fmt.Sprintf("already cut at %s, %d commits behind %s", shortSHA(plan.Current), comparison.GetBehindBy(), ref)
+		return plan, nil
+	}
+
+	plan.Action = ActionDiverged
+	switch comparison.GetStatus() {
+	case "ahead":
+		plan.Why = fmt.Sprintf("%s is %d commits ahead of %s", branch, comparison.GetAheadBy(), shortSHA(sha))
+	case "behind":
+		plan.Why = fmt.Sprintf("%s is %d commits behind %s", branch, comparison.GetBehindBy(), shortSHA(sha))
+	default:
+		plan.Why = fmt.Sprintf("%s has diverged from %s", branch, shortSHA(sha))
+	}
+	return plan, nil
+}
+
+// CutBranch creates the branch of the plan, it only acts on plans creating a
+// branch
+func (r *Releaser) CutBranch(ctx context.Context, plan *BranchPlan) error {
+	if plan.Action != ActionCreate {
+		return nil
+	}
+	_, _, err := r.client.Git.CreateRef(ctx, plan.Org, plan.Repo, &github.Reference{
+		Ref:    github.String("refs/heads/" + plan.Branch),
+		Object: &github.GitObject{SHA: github.String(plan.SHA)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", plan.Branch, err)
+	}
+	return nil
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,77 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestPlanBranch(t *testing.T) {
+	const head = "1111111111111111111111111111111111111111"
+	const old = "2222222222222222222222222222222222222222"
+
+	// The commit of the release branch and how it compares with the head of
+	// main in every repo
+	branches := map[string]struct{ sha, status string }{
+		"cut":      {sha: head},
+		"behind":   {sha: old, status: "behind"},
+		"ahead":    {sha: old, status: "ahead"},
+		"diverged": {sha: old, status: "diverged"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/konveyor/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/repos/konveyor/"), "/", 2)
+		b, ok := branches[parts[0]]
+		switch {
+		case len(parts) == 1:
+			fmt.Fprint(w, `{"default_branch": "main"}`)
+		case strings.HasPrefix(parts[1], "commits/"):
+			fmt.Fprint(w, head)
+		case parts[1] == "git/ref/heads/release-0.8" && ok:
+			fmt.Fprintf(w, `{"ref": "refs/heads/release-0.8", "object": {"type": "commit", "sha": %q}}`, b.sha)
+		case parts[1] == fmt.Sprintf("compare/%s...%s", head, old) && ok:
+			fmt.Fprintf(w, `{"status": %q, "ahead_by": 1, "behind_by": 3}`, b.status)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	releaser := NewReleaser(client)
+
+	tests := []struct {
+		repo       string
+		ref        string
+		wantAction string
+	}{
+		{repo: "missing", wantAction: ActionCreate},
+		{repo: "cut", wantAction: ActionUnchanged},
+		// main moved on since the branch was cut
+		{repo: "behind", wantAction: ActionUnchanged},
+		// The branch is not at the commit it was asked to be cut from
+		{repo: "behind", ref: head, wantAction: ActionDiverged},
+		{repo: "ahead", wantAction: ActionDiverged},
+		{repo: "diverged", wantAction: ActionDiverged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repo+"@"+tt.ref, func(t *testing.T) {
+			plan, err := releaser.PlanBranch(context.Background(), "konveyor", tt.repo, "release-0.8", tt.ref)
+			if err != nil {
+				t.Fatalf("PlanBranch() error = %v", err)
+			}
+			if plan.Action != tt.wantAction || plan.SHA != head {
+				t.Errorf("PlanBranch() = %s from %s, want %s from %s (%s)", plan.Action, plan.SHA, tt.wantAction, head, plan.Why)
+			}
+		})
+	}
+}
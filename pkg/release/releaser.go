@@ -0,0 +1,169 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// Actions a Plan can take
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionUnchanged = "unchanged"
+)
+
+// Spec is the GitHub release wanted
+type Spec struct {
+	Tag string `json:"tag"`
+	// Target is the SHA the tag is created on
+	Target     string `json:"target"`
+	Name       string `json:"name"`
+	Body       string `json:"-"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// Plan is what is needed to get the wanted release
+type Plan struct {
+	Org    string `json:"org"`
+	Repo   string `json:"repo"`
+	Action string `json:"action"`
+	Spec   Spec   `json:"spec"`
+	// Why explains the update, e.g. "body changed"
+	Why []string `json:"why,omitempty"`
+
+	existing *github.RepositoryRelease
+}
+
+// Releaser creates and updates GitHub releases
+type Releaser struct {
+	client *github.Client
+}
+
+// NewReleaser creates a new releaser with the given GitHub client
+func NewReleaser(client *github.Client) *Releaser {
+	return &Releaser{client: client}
+}
+
+// Plan compares the wanted release with the existing one so that re-running a
+// release only changes what differs. A published release is never turned
+// back into a draft, and an existing tag must point at the target.
+func (r *Releaser) Plan(ctx context.Context, org, repo string, spec Spec) (*Plan, error) {
+	plan := &Plan{Org: org, Repo: repo, Spec: spec}
+
+	ref, resp, err := r.client.Git.GetRef(ctx, org, repo, "tags/"+spec.Tag)
+	switch {
+	case err == nil:
+		sha, err := tagCommit(ctx, r.client, org, repo, ref)
+		if err != nil {
+			return nil, err
+		}
+		if sha != spec.Target {
+			return nil, fmt.Errorf("tag %s already exists on %s, not on %s", spec.Tag, sha, spec.Target)
+		}
+	case resp == nil || resp.StatusCode != http.StatusNotFound:
+		return nil, fmt.Errorf("failed to get tag %s: %w", spec.Tag, err)
+	}
+
+	existing, resp, err := r.client.Repositories.GetReleaseByTag(ctx, org, repo, spec.Tag)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// Drafts are not found by tag until published
+			existing, err = r.findDraft(ctx, org, repo, spec.Tag)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get release %s: %w", spec.Tag, err)
+		}
+	}
+	if existing == nil {
+		plan.Action = ActionCreate
+		return plan, nil
+	}
+	plan.existing = existing
+
+	if !existing.GetDraft() && spec.Draft {
+		plan.Spec.Draft = false
+	}
+	if existing.GetName() != plan.Spec.Name {
+		plan.Why = append(plan.Why, "name changed")
+	}
+	if existing.GetBody() != plan.Spec.Body {
+		plan.Why = append(plan.Why, "release notes changed")
+	}
+	if existing.GetDraft() != plan.Spec.Draft {
+		plan.Why = append(plan.Why, "published")
+	}
+	if existing.GetPrerelease() != plan.Spec.Prerelease {
+		plan.Why = append(plan.Why, "pre-release changed")
+	}
+
+	plan.Action = ActionUnchanged
+	if len(plan.Why) > 0 {
+		plan.Action = ActionUpdate
+	}
+	return plan, nil
+}
+
+// Apply creates or updates the release of the plan
+func (r *Releaser) Apply(ctx context.Context, plan *Plan) (*github.RepositoryRelease, error) {
+	release := &github.RepositoryRelease{
+		TagName:         github.String(plan.Spec.Tag),
+		TargetCommitish: github.String(plan.Spec.Target),
+		Name:            github.String(plan.Spec.Name),
+		Body:            github.String(plan.Spec.Body),
+		Draft:           github.Bool(plan.Spec.Draft),
+		Prerelease:      github.Bool(plan.Spec.Prerelease),
+	}
+
+	switch plan.Action {
+	case ActionCreate:
+		created, _, err := r.client.Repositories.CreateRelease(ctx, plan.Org, plan.Repo, release)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create release %s: %w", plan.Spec.Tag, err)
+		}
+		return created, nil
+	case ActionUpdate:
+		updated, _, err := r.client.Repositories.EditRelease(ctx, plan.Org, plan.Repo, plan.existing.GetID(), release)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update release %s: %w", plan.Spec.Tag, err)
+		}
+		return updated, nil
+	}
+	return plan.existing, nil
+}
+
+// tagCommit returns the commit a tag points at, peeling annotated tags
+func tagCommit(ctx context.Context, client *github.Client, org, repo string, ref *github.Reference) (string, error) {
+	if ref.GetObject().GetType() != "tag" {
+		return ref.GetObject().GetSHA(), nil
+	}
+	tag, _, err := client.Git.GetTag(ctx, org, repo, ref.GetObject().GetSHA())
+	if err != nil {
+		return "", fmt.Errorf("failed to get annotated tag %s: %w", ref.GetRef(), err)
+	}
+	return tag.GetObject().GetSHA(), nil
+}
+
+// findDraft returns the draft release of the tag, nil when there is none
+func (r *Releaser) findDraft(ctx context.Context, org, repo, tag string) (*github.RepositoryRelease, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		releases, resp, err := r.client.Repositories.ListReleases(ctx, org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, release := range releases {
+			if release.GetDraft() && release.GetTagName() == tag {
+				return release, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return nil, nil
+}
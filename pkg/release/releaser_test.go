@@ -0,0 +1,95 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestPlanApply(t *testing.T) {
+	const target = "1111111111111111111111111111111111111111"
+	const old = "2222222222222222222222222222222222222222"
+
+	var writes []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/konveyor/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writes = append(writes, r.Method+" "+r.URL.Path)
+			fmt.Fprint(w, `{"id": 1}`)
+			return
+		}
+		switch r.URL.Path {
+		// A draft is only listed, it is not found by tag
+		case "/repos/konveyor/draft/releases":
+			fmt.Fprint(w, `[{"id": 2, "tag_name": "v0.8.0", "name": "v0.8.0", "body": "old notes", "draft": true}]`)
+		case "/repos/konveyor/missing/releases":
+			fmt.Fprint(w, `[]`)
+		case "/repos/konveyor/unchanged/git/ref/tags/v0.8.0",
+			"/repos/konveyor/updated/git/ref/tags/v0.8.0":
+			fmt.Fprintf(w, `{"ref": "refs/tags/v0.8.0", "object": {"type": "commit", "sha": %q}}`, target)
+		case "/repos/konveyor/moved/git/ref/tags/v0.8.0":
+			fmt.Fprintf(w, `{"ref": "refs/tags/v0.8.0", "object": {"type": "commit", "sha": %q}}`, old)
+		case "/repos/konveyor/unchanged/releases/tags/v0.8.0":
+			fmt.Fprint(w, `{"id": 3, "tag_name": "v0.8.0", "name": "v0.8.0", "body": "notes"}`)
+		case "/repos/konveyor/updated/releases/tags/v0.8.0":
+			fmt.Fprint(w, `{"id": 4, "tag_name": "v0.8.0", "name": "v0.8.0", "body": "old notes"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	releaser := NewReleaser(client)
+
+	tests := []struct {
+		repo       string
+		draft      bool
+		wantAction string
+		wantWhy    []string
+		wantWrite  string
+		wantErr    bool
+	}{
+		{repo: "missing", draft: true, wantAction: ActionCreate, wantWrite: "POST /repos/konveyor/missing/releases"},
+		{repo: "draft", wantAction: ActionUpdate, wantWhy: []string{"release notes changed", "published"}, wantWrite: "PATCH /repos/konveyor/draft/releases/2"},
+		// A published release is not turned back into a draft
+		{repo: "unchanged", draft: true, wantAction: ActionUnchanged},
+		{repo: "updated", wantAction: ActionUpdate, wantWhy: []string{"release notes changed"}, wantWrite: "PATCH /repos/konveyor/updated/releases/4"},
+		{repo: "moved", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repo, func(t *testing.T) {
+			writes = nil
+			spec := Spec{Tag: "v0.8.0", Target: target, Name: "v0.8.0", Body: "notes", Draft: tt.draft}
+			plan, err := releaser.Plan(context.Background(), "konveyor", tt.repo, spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Plan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if plan.Action != tt.wantAction || !reflect.DeepEqual(plan.Why, tt.wantWhy) {
+				t.Errorf("Plan() = %s %v, want %s %v", plan.Action, plan.Why, tt.wantAction, tt.wantWhy)
+			}
+
+			if _, err := releaser.Apply(context.Background(), plan); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			var wantWrites []string
+			if tt.wantWrite != "" {
+				wantWrites = []string{tt.wantWrite}
+			}
+			if !reflect.DeepEqual(writes, wantWrites) {
+				t.Errorf("Apply() wrote %v, want %v", writes, wantWrites)
+			}
+		})
+	}
+}
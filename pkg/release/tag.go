@@ -0,0 +1,106 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// TagPlan is what is needed to tag the head of a release branch in a repo
+type TagPlan struct {
+	Org    string `json:"org"`
+	Repo   string `json:"repo"`
+	Tag    string `json:"tag"`
+	Branch string `json:"branch"`
+	// SHA is the head of the branch the tag is created on
+	SHA    string `json:"sha"`
+	Action string `json:"action"`
+	// Current is the commit an existing tag points at
+	Current string `json:"current,omitempty"`
+	Why     string `json:"why,omitempty"`
+}
+
+// Target is the commit the release is made of: the existing tag, or the head
+// of the branch the tag is created on
+func (p *TagPlan) Target() string {
+	if p.Current != "" {
+		return p.Current
+	}
+	return p.SHA
+}
+
+// PlanTag plans tagging the head of the branch. An existing tag is left
+// unchanged when it points at the head or at an older commit of the branch,
+// so that a release is resumed after fixes landed, and reported as diverged
+// otherwise. It returns an error when the branch does not exist.
+func (r *Releaser) PlanTag(ctx context.Context, org, repo, tag, branch string) (*TagPlan, error) {
+	plan := &TagPlan{Org: org, Repo: repo, Tag: tag, Branch: branch}
+
+	b, resp, err := r.client.Repositories.GetBranch(ctx, org, repo, branch, true)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("branch %s does not exist", branch)
+		}
+		return nil, fmt.Errorf("failed to get branch %s: %w", branch, err)
+	}
+	plan.SHA = b.GetCommit().GetSHA()
+
+	ref, resp, err := r.client.Git.GetRef(ctx, org, repo, "tags/"+tag)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			plan.Action = ActionCreate
+			plan.Why = "missing"
+			return plan, nil
+		}
+		return nil, fmt.Errorf("failed to get tag %s: %w", tag, err)
+	}
+
+	plan.Current, err = tagCommit(ctx, r.client, org, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Current == plan.SHA {
+		plan.Action = ActionUnchanged
+		plan.Why = "already tagged at " + shortSHA(plan.SHA)
+		return plan, nil
+	}
+
+	comparison, _, err := r.client.Repositories.CompareCommits(ctx, org, repo, plan.SHA, plan.Current, &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s with %s: %w", tag, branch, err)
+	}
+	if status := comparison.GetStatus(); status == "identical" || status == "behind" {
+		plan.Action = ActionUnchanged
+		plan.Why = fmt.Sprintf("already tagged at %s, %d commits behind %s", shortSHA(plan.Current), comparison.GetBehindBy(), branch)
+		return plan, nil
+	}
+	plan.Action = ActionDiverged
+	plan.Why = fmt.Sprintf("%s is on %s, not on the head of %s %s", tag, shortSHA(plan.Current), branch, shortSHA(plan.SHA))
+	return plan, nil
+}
+
+// CreateTag creates the annotated tag of the plan, it only acts on plans
+// creating a tag
+func (r *Releaser) CreateTag(ctx context.Context, plan *TagPlan, message string) error {
+	if plan.Action != ActionCreate {
+		return nil
+	}
+	tag, _, err := r.client.Git.CreateTag(ctx, plan.Org, plan.Repo, &github.Tag{
+		Tag:     github.String(plan.Tag),
+		Message: github.String(message),
+		Object:  &github.GitObject{Type: github.String("commit"), SHA: github.String(plan.SHA)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tag object %s: %w", plan.Tag, err)
+	}
+	_, _, err = r.client.Git.CreateRef(ctx, plan.Org, plan.Repo, &github.Reference{
+		Ref:    github.String("refs/tags/" + plan.Tag),
+		Object: &github.GitObject{SHA: tag.SHA},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", plan.Tag, err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestPlanTag(t *testing.T) {
+	const head = "1111111111111111111111111111111111111111"
+	const old = "2222222222222222222222222222222222222222"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/konveyor/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/konveyor/operator/branches/release-0.8",
+			"/repos/konveyor/tagged/branches/release-0.8",
+			"/repos/konveyor/annotated/branches/release-0.8",
+			"/repos/konveyor/moved/branches/release-0.8",
+			"/repos/konveyor/resumed/branches/release-0.8":
+			fmt.Fprintf(w, `{"name": "release-0.8", "commit": {"sha": %q}}`, head)
+		case "/repos/konveyor/tagged/git/ref/tags/v0.8.0":
+			fmt.Fprintf(w, `{"ref": "refs/tags/v0.8.0", "object": {"type": "commit", "sha": %q}}`, head)
+		case "/repos/konveyor/annotated/git/ref/tags/v0.8.0":
+			fmt.Fprint(w, `{"ref": "refs/tags/v0.8.0", "object": {"type": "tag", "sha": "3333"}}`)
+		case "/repos/konveyor/annotated/git/tags/3333":
+			fmt.Fprintf(w, `{"tag": "v0.8.0", "object": {"type": "commit", "sha": %q}}`, head)
+		case "/repos/konveyor/moved/git/ref/tags/v0.8.0",
+			"/repos/konveyor/resumed/git/ref/tags/v0.8.0":
+			fmt.Fprintf(w, `{"ref": "refs/tags/v0.8.0", "object": {"type": "commit", "sha": %q}}`, old)
+		case "/repos/konveyor/moved/compare/" + head + "..." + old:
+			fmt.Fprint(w, `{"status": "diverged", "ahead_by": 1, "behind_by": 2}`)
+		// Fixes landed on the branch after it was tagged
+		case "/repos/konveyor/resumed/compare/" + head + "..." + old:
+			fmt.Fprint(w, `{"status": "behind", "behind_by": 2}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	releaser := NewReleaser(client)
+
+	tests := []struct {
+		repo       string
+		wantAction string
+		wantTarget string
+		wantErr    bool
+	}{
+		{repo: "operator", wantAction: ActionCreate, wantTarget: head},
+		{repo: "tagged", wantAction: ActionUnchanged, wantTarget: head},
+		{repo: "annotated", wantAction: ActionUnchanged, wantTarget: head},
+		{repo: "resumed", wantAction: ActionUnchanged, wantTarget: old},
+		{repo: "moved", wantAction: ActionDiverged, wantTarget: old},
+		{repo: "missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repo, func(t *testing.T) {
+			plan, err := releaser.PlanTag(context.Background(), "konveyor", tt.repo, "v0.8.0", "release-0.8")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlanTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if plan.Action != tt.wantAction || plan.SHA != head || plan.Target() != tt.wantTarget {
+				t.Errorf("PlanTag() = %s of %s on %s, want %s of %s on %s (%s)", plan.Action, plan.Target(), plan.SHA, tt.wantAction, tt.wantTarget, head, plan.Why)
+			}
+		})
+	}
+}
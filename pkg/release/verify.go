@@ -0,0 +1,186 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/action"
+	"github.com/konveyor/release-tools/pkg/registry"
+	"github.com/konveyor/release-tools/pkg/semver"
+)
+
+// Check is the result of one verification of a repo, e.g. its tag
+type Check struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message"`
+}
+
+// Verification is the result of the checks of a repo
+type Verification struct {
+	Org    string  `json:"org"`
+	Repo   string  `json:"repo"`
+	Checks []Check `json:"checks"`
+}
+
+// OK returns whether every check of the repo passed
+func (v Verification) OK() bool {
+	for _, c := range v.Checks {
+		if !c.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// Report is the verification of a release in every repo
+type Report struct {
+	Version string         `json:"version"`
+	Repos   []Verification `json:"repos"`
+}
+
+// OK returns whether every repo passed
+func (r *Report) OK() bool {
+	for _, v := range r.Repos {
+		if !v.OK() {
+			return false
+		}
+	}
+	return true
+}
+
+// Verifier checks a release was published everywhere
+type Verifier struct {
+	client   *github.Client
+	registry *registry.Client
+}
+
+// NewVerifier creates a new verifier with the given GitHub and registry
+// clients
+func NewVerifier(client *github.Client, registry *registry.Client) *Verifier {
+	return &Verifier{client: client, registry: registry}
+}
+
+// Verify checks the tag of the version exists in the repo, its GitHub release
+// is published as a pre-release only for pre-release versions, and every
+// image of the repo is tagged with the version
+func (v *Verifier) Verify(ctx context.Context, org, repo string, images []string, version semver.Version) Verification {
+	result := Verification{Org: org, Repo: repo}
+	tag := version.String()
+
+	result.Checks = append(result.Checks, v.checkTag(ctx, org, repo, tag))
+	result.Checks = append(result.Checks, v.checkRelease(ctx, org, repo, tag, version.IsPrerelease()))
+	for _, image := range images {
+		result.Checks = append(result.Checks, v.checkImage(ctx, image, tag))
+	}
+	return result
+}
+
+func (v *Verifier) checkTag(ctx context.Context, org, repo, tag string) Check {
+	check := Check{Name: "tag"}
+	ref, resp, err := v.client.Git.GetRef(ctx, org, repo, "tags/"+tag)
+	switch {
+	case err == nil:
+		sha, err := tagCommit(ctx, v.client, org, repo, ref)
+		if err != nil {
+			check.Message = err.Error()
+			break
+		}
+		check.OK = true
+		check.Message = fmt.Sprintf("%s is on %s", tag, shortSHA(sha))
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		check.Message = tag + " is missing"
+	default:
+		check.Message = fmt.Sprintf("failed to get tag %s: %v", tag, err)
+	}
+	return check
+}
+
+func (v *Verifier) checkRelease(ctx context.Context, org, repo, tag string, prerelease bool) Check {
+	check := Check{Name: "release"}
+	// A draft is not returned by tag
+	release, resp, err := v.client.Repositories.GetReleaseByTag(ctx, org, repo, tag)
+	switch {
+	case err == nil:
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		check.Message = "no published release of " + tag
+		return check
+	default:
+		check.Message = fmt.Sprintf("failed to get release %s: %v", tag, err)
+		return check
+	}
+
+	switch {
+	case release.GetDraft():
+		check.Message = release.GetHTMLURL() + " is a draft"
+	case release.GetPrerelease() != prerelease:
+		check.Message = fmt.Sprintf("%s has pre-release %t, expected %t", release.GetHTMLURL(), release.GetPrerelease(), prerelease)
+	default:
+		check.OK = true
+		check.Message = release.GetHTMLURL()
+	}
+	return check
+}
+
+func (v *Verifier) checkImage(ctx context.Context, image, tag string) Check {
+	check := Check{Name: "image " + image}
+	exists, err := v.registry.TagExists(ctx, image, tag)
+	switch {
+	case err != nil:
+		check.Message = err.Error()
+	case !exists:
+		check.Message = fmt.Sprintf("%s:%s is missing", image, tag)
+	default:
+		check.OK = true
+		check.Message = fmt.Sprintf("%s:%s", image, tag)
+	}
+	return check
+}
+
+// RenderReport renders the report in the format
+func RenderReport(r *Report, format string) (string, error) {
+	switch format {
+	case action.FormatMarkdown, "md":
+		return RenderReportMarkdown(r), nil
+	case action.FormatJSON:
+		data, err := action.MarshalJSON(r)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal the verification report: %w", err)
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("unknown verification report format %q, expected %s or %s", format, action.FormatMarkdown, action.FormatJSON)
+}
+
+// RenderReportMarkdown renders the report as one row per check, failures of
+// every repo first
+func RenderReportMarkdown(r *Report) string {
+	var b strings.Builder
+
+	verdict := ":white_check_mark: published"
+	if !r.OK() {
+		verdict = ":x: incomplete"
+	}
+	fmt.Fprintf(&b, "# Verification of %s: %s\n\n", r.Version, verdict)
+
+	b.WriteString("| Repository | Check | Status | Details |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, ok := range []bool{false, true} {
+		for _, v := range r.Repos {
+			for _, c := range v.Checks {
+				if c.OK != ok {
+					continue
+				}
+				status := ":x:"
+				if c.OK {
+					status = ":white_check_mark:"
+				}
+				fmt.Fprintf(&b, "| %s/%s | %s | %s | %s |\n", v.Org, v.Repo, c.Name, status, strings.ReplaceAll(c.Message, "|", "\\|"))
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/konveyor/release-tools/pkg/registry"
+	"github.com/konveyor/release-tools/pkg/semver"
+)
+
+func TestVerify(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/konveyor/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/konveyor/tackle2-hub/git/ref/tags/v0.8.0",
+			"/repos/konveyor/prerelease/git/ref/tags/v0.8.0",
+			"/repos/konveyor/untagged-image/git/ref/tags/v0.8.0":
+			fmt.Fprint(w, `{"ref": "refs/tags/v0.8.0", "object": {"type": "commit", "sha": "1111111111111111"}}`)
+		// An annotated tag points at the tag object, which points at the commit
+		case "/repos/konveyor/annotated/git/ref/tags/v0.8.0":
+			fmt.Fprint(w, `{"ref": "refs/tags/v0.8.0", "object": {"type": "tag", "sha": "aaaaaaaaaaaaaaaa"}}`)
+		case "/repos/konveyor/annotated/git/tags/aaaaaaaaaaaaaaaa":
+			fmt.Fprint(w, `{"sha": "aaaaaaaaaaaaaaaa", "object": {"type": "commit", "sha": "2222222222222222"}}`)
+		case "/repos/konveyor/annotated/releases/tags/v0.8.0":
+			fmt.Fprint(w, `{"tag_name": "v0.8.0", "prerelease": false}`)
+		case "/repos/konveyor/tackle2-hub/releases/tags/v0.8.0",
+			"/repos/konveyor/untagged-image/releases/tags/v0.8.0":
+			fmt.Fprint(w, `{"tag_name": "v0.8.0", "prerelease": false}`)
+		case "/repos/konveyor/prerelease/releases/tags/v0.8.0":
+			fmt.Fprint(w, `{"tag_name": "v0.8.0", "prerelease": true}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	mux.HandleFunc("/v2/konveyor/tackle2-hub/manifests/v0.8.0", func(w http.ResponseWriter, r *http.Request) {})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	verifier := NewVerifier(client, registry.NewClient(registry.Options{
+		Host:      strings.TrimPrefix(server.URL, "http://"),
+		PlainHTTP: true,
+	}))
+	v, _ := semver.Parse("v0.8.0")
+
+	tests := []struct {
+		repo   string
+		images []string
+		// failed are the names of the failed checks
+		failed []string
+		// tagged is the message of the tag check when it passes
+		tagged string
+	}{
+		{repo: "tackle2-hub", images: []string{"quay.io/konveyor/tackle2-hub"}, tagged: "v0.8.0 is on 1111111"},
+		{repo: "annotated", tagged: "v0.8.0 is on 2222222"},
+		{repo: "prerelease", failed: []string{"release"}},
+		{repo: "untagged-image", images: []string{"quay.io/konveyor/untagged-image"}, failed: []string{"image quay.io/konveyor/untagged-image"}},
+		{repo: "missing", failed: []string{"tag", "release"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repo, func(t *testing.T) {
+			got := verifier.Verify(context.Background(), "konveyor", tt.repo, tt.images, v)
+			var failed []string
+			for _, c := range got.Checks {
+				if !c.OK {
+					failed = append(failed, c.Name)
+				}
+			}
+			if fmt.Sprint(failed) != fmt.Sprint(tt.failed) {
+				t.Errorf("Verify() failed %v, want %v: %+v", failed, tt.failed, got.Checks)
+			}
+			if tt.tagged != "" && got.Checks[0].Message != tt.tagged {
+				t.Errorf("Verify() tag check = %q, want %q", got.Checks[0].Message, tt.tagged)
+			}
+			if got.OK() != (len(tt.failed) == 0) {
+				t.Errorf("OK() = %t, want %t", got.OK(), len(tt.failed) == 0)
+			}
+		})
+	}
+}
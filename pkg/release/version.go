@@ -0,0 +1,32 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konveyor/release-tools/pkg/semver"
+)
+
+// ValidateVersion checks the version follows VERSIONING.md for a release made
+// from branch. Pre-releases are of the next minor, vX.Y.0-pre. Releases from
+// main are pre-releases and releases from release-X.Y are of X.Y. Other refs,
+// e.g. a SHA, are only checked against the version format.
+func ValidateVersion(tag, branch string) (semver.Version, error) {
+	v, err := semver.Parse(tag)
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	if v.IsPrerelease() && v.Patch != 0 {
+		return semver.Version{}, fmt.Errorf("pre-release %s must be of the next minor, e.g. v%d.%d.0-%s", tag, v.Major, v.Minor, v.Pre)
+	}
+
+	branch = strings.TrimPrefix(branch, "refs/heads/")
+	if branch == "main" && !v.IsPrerelease() {
+		return semver.Version{}, fmt.Errorf("releases from main must be pre-releases like v%s.0-alpha.1, %s must be released from release-%s", v.XY(), tag, v.XY())
+	}
+	if major, minor, ok := semver.ReleaseBranch(branch); ok && (major != v.Major || minor != v.Minor) {
+		return semver.Version{}, fmt.Errorf("%s can't be released from %s, only v%d.%d.Z versions can", tag, branch, major, minor)
+	}
+	return v, nil
+}
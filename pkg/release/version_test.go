@@ -0,0 +1,31 @@
+package release
+
+import (
+	"testing"
+)
+
+func TestValidateVersion(t *testing.T) {
+	testCases := []struct {
+		tag     string
+		branch  string
+		wantErr bool
+	}{
+		{tag: "v0.8.0-alpha.1", branch: "main"},
+		{tag: "v0.8.0-alpha.1", branch: "refs/heads/main"},
+		{tag: "v0.8.0", branch: "main", wantErr: true},
+		{tag: "v0.8.1-alpha.1", branch: "main", wantErr: true},
+		{tag: "v0.8.0", branch: "release-0.8"},
+		{tag: "v0.8.2", branch: "refs/heads/release-0.8"},
+		{tag: "v0.8.0-rc.1", branch: "release-0.8"},
+		{tag: "v0.9.0", branch: "release-0.8", wantErr: true},
+		{tag: "v0.9.0", branch: "0123456789abcdef0123456789abcdef01234567"},
+		{tag: "0.9.0", branch: "release-0.9", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		_, err := ValidateVersion(tc.tag, tc.branch)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("Expected error %v for %s from %s but got %v", tc.wantErr, tc.tag, tc.branch, err)
+		}
+	}
+}
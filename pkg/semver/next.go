@@ -0,0 +1,146 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/konveyor/release-tools/pkg/config"
+)
+
+// Matches the release-X.Y branches described in VERSIONING.md
+var releaseBranchRegex = regexp.MustCompile(`^release-(0|[1-9]\d*)\.(0|[1-9]\d*)$`)
+
+// Matches the pre-releases made from main, e.g. "alpha.2"
+var alphaRegex = regexp.MustCompile(`^alpha\.(\d+)$`)
+
+var impactOrder = map[config.SemverImpact]int{
+	config.ImpactNone:  0,
+	config.ImpactPatch: 1,
+	config.ImpactMinor: 2,
+	config.ImpactMajor: 3,
+}
+
+// MaxImpact returns the highest of the impacts, none when there are none
+func MaxImpact(impacts ...config.SemverImpact) config.SemverImpact {
+	max := config.ImpactNone
+	for _, impact := range impacts {
+		if impactOrder[impact] > impactOrder[max] {
+			max = impact
+		}
+	}
+	return max
+}
+
+// ReleaseBranch returns the X.Y of a release-X.Y branch
+func ReleaseBranch(branch string) (int, int, bool) {
+	m := releaseBranchRegex.FindStringSubmatch(strings.TrimPrefix(branch, "refs/heads/"))
+	if m == nil {
+		return 0, 0, false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	return major, minor, true
+}
+
+// Latest returns the highest tag accepted by the filter
+func Latest(tags []string, filter func(Version) bool) (Version, bool) {
+	var latest Version
+	found := false
+	for _, tag := range tags {
+		v, err := Parse(tag)
+		if err != nil || !filter(v) {
+			continue
+		}
+		if !found || Compare(v, latest) > 0 {
+			latest, found = v, true
+		}
+	}
+	return latest, found
+}
+
+// Next suggests the next version of a branch given the tags of the repo and
+// the impact of the PRs merged since the last tag of the branch. Releases
+// from main are vX.Y.0-alpha.n pre-releases of the next minor, or major once
+// the major is not 0 and breaking changes are merged. Releases from
+// release-X.Y are vX.Y.0 and then the next patch. The release-X.Y branches of
+// the repo are needed on main, as the next minor follows the last one that
+// was branched.
+func Next(tags []string, branch string, releaseBranches []string, impact config.SemverImpact) (Version, error) {
+	if major, minor, ok := ReleaseBranch(branch); ok {
+		return NextPatch(tags, major, minor), nil
+	}
+	if strings.TrimPrefix(branch, "refs/heads/") != "main" {
+		return Version{}, fmt.Errorf("releases are only made from main and release-X.Y branches, not %q", branch)
+	}
+	return NextAlpha(tags, releaseBranches, impact), nil
+}
+
+// NextPatch returns the next release of the release-X.Y branch
+func NextPatch(tags []string, major, minor int) Version {
+	latest, ok := Latest(tags, func(v Version) bool {
+		return v.Major == major && v.Minor == minor && !v.IsPrerelease()
+	})
+	if !ok {
+		return Version{Major: major, Minor: minor}
+	}
+	latest.Patch++
+	return latest
+}
+
+// NextAlpha returns the next pre-release from main
+func NextAlpha(tags []string, releaseBranches []string, impact config.SemverImpact) Version {
+	// The last minor that was released or branched for release
+	base, _ := Latest(tags, func(v Version) bool { return !v.IsPrerelease() })
+	for _, branch := range releaseBranches {
+		if major, minor, ok := ReleaseBranch(branch); ok {
+			if v := (Version{Major: major, Minor: minor}); Compare(v, base) > 0 {
+				base = v
+			}
+		}
+	}
+
+	target := Version{Major: base.Major, Minor: base.Minor + 1}
+	if impact == config.ImpactMajor && base.Major > 0 {
+		target = Version{Major: base.Major + 1}
+	}
+
+	// Continue a pre-release series of a higher target, e.g. when a breaking
+	// change already moved main to the next major
+	if latest, ok := Latest(tags, func(v Version) bool { return v.IsPrerelease() }); ok {
+		core := Version{Major: latest.Major, Minor: latest.Minor, Patch: latest.Patch}
+		if Compare(core, target) > 0 {
+			target = core
+		}
+	}
+
+	n := 0
+	for _, tag := range tags {
+		v, err := Parse(tag)
+		if err != nil || v.Major != target.Major || v.Minor != target.Minor || v.Patch != target.Patch {
+			continue
+		}
+		if m := alphaRegex.FindStringSubmatch(v.Pre); m != nil {
+			if i, _ := strconv.Atoi(m[1]); i > n {
+				n = i
+			}
+		}
+	}
+	target.Pre = fmt.Sprintf("alpha.%d", n+1)
+	return target
+}
+
+// LastTag returns the last tag of the branch, the highest tag for main and
+// the highest vX.Y.Z tag for release-X.Y, to find the PRs merged since
+func LastTag(tags []string, branch string) (string, bool) {
+	filter := func(Version) bool { return true }
+	if major, minor, ok := ReleaseBranch(branch); ok {
+		filter = func(v Version) bool { return v.Major == major && v.Minor == minor }
+	}
+	latest, ok := Latest(tags, filter)
+	if !ok {
+		return "", false
+	}
+	return latest.String(), true
+}
@@ -0,0 +1,100 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/konveyor/release-tools/pkg/config"
+)
+
+func TestNext(t *testing.T) {
+	testCases := []struct {
+		name            string
+		tags            []string
+		branch          string
+		releaseBranches []string
+		impact          config.SemverImpact
+		expected        string
+	}{
+		{
+			name:     "first pre-release",
+			branch:   "main",
+			impact:   config.ImpactMinor,
+			expected: "v0.1.0-alpha.1",
+		},
+		{
+			name:     "first pre-release of the next minor",
+			tags:     []string{"v0.7.0", "v0.7.2", "v0.7.0-alpha.3"},
+			branch:   "main",
+			impact:   config.ImpactPatch,
+			expected: "v0.8.0-alpha.1",
+		},
+		{
+			name:     "next pre-release",
+			tags:     []string{"v0.7.2", "v0.8.0-alpha.1", "v0.8.0-alpha.2"},
+			branch:   "main",
+			impact:   config.ImpactMinor,
+			expected: "v0.8.0-alpha.3",
+		},
+		{
+			name:     "breaking changes bump the minor while major is 0",
+			tags:     []string{"v0.7.2", "v0.8.0-alpha.1"},
+			branch:   "main",
+			impact:   config.ImpactMajor,
+			expected: "v0.8.0-alpha.2",
+		},
+		{
+			name:     "breaking changes bump the major",
+			tags:     []string{"v1.2.0", "v1.3.0-alpha.1"},
+			branch:   "main",
+			impact:   config.ImpactMajor,
+			expected: "v2.0.0-alpha.1",
+		},
+		{
+			name:            "main follows the last release branch",
+			tags:            []string{"v0.7.2", "v0.8.0-alpha.4"},
+			branch:          "main",
+			releaseBranches: []string{"release-0.7", "release-0.8"},
+			impact:          config.ImpactPatch,
+			expected:        "v0.9.0-alpha.1",
+		},
+		{
+			name:     "first release of a release branch",
+			tags:     []string{"v0.7.2", "v0.8.0-alpha.4"},
+			branch:   "release-0.8",
+			impact:   config.ImpactPatch,
+			expected: "v0.8.0",
+		},
+		{
+			name:     "next patch of a release branch",
+			tags:     []string{"v0.7.2", "v0.8.0", "v0.8.1", "v0.9.0-alpha.1"},
+			branch:   "refs/heads/release-0.8",
+			impact:   config.ImpactPatch,
+			expected: "v0.8.2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := Next(tc.tags, tc.branch, tc.releaseBranches, tc.impact)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if v.String() != tc.expected {
+				t.Errorf("Expected %s but got %s", tc.expected, v)
+			}
+		})
+	}
+
+	if _, err := Next(nil, "feature-branch", nil, config.ImpactPatch); err == nil {
+		t.Errorf("Expected releases from feature-branch to be rejected")
+	}
+}
+
+func TestMaxImpact(t *testing.T) {
+	if impact := MaxImpact(); impact != config.ImpactNone {
+		t.Errorf("Expected none but got %s", impact)
+	}
+	if impact := MaxImpact(config.ImpactPatch, config.ImpactMinor, config.ImpactNone); impact != config.ImpactMinor {
+		t.Errorf("Expected minor but got %s", impact)
+	}
+}
@@ -0,0 +1,138 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Matches the vX.Y.Z[-pre] tags described in VERSIONING.md
+var versionRegex = regexp.MustCompile(`^v(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+
+// Version is a semantic version tag
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	// Pre is the pre-release, e.g. "alpha.2", empty for releases
+	Pre string
+}
+
+// Parse parses a vX.Y.Z[-pre] tag
+func Parse(tag string) (Version, error) {
+	m := versionRegex.FindStringSubmatch(tag)
+	if m == nil {
+		return Version{}, fmt.Errorf("%q is not a semantic version like v1.2.3 or v1.2.3-alpha.1", tag)
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	patch, _ := strconv.Atoi(m[3])
+	return Version{Major: major, Minor: minor, Patch: patch, Pre: m[4]}, nil
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// IsPrerelease returns true for versions with a pre-release, e.g.
+// v1.2.0-alpha.1
+func (v Version) IsPrerelease() bool {
+	return v.Pre != ""
+}
+
+// Compare returns -1, 0 or 1 if a is lower, equal or greater than b,
+// following the precedence rules of semantic versioning
+func Compare(a, b Version) int {
+	for _, c := range [][2]int{{a.Major, b.Major}, {a.Minor, b.Minor}, {a.Patch, b.Patch}} {
+		if c[0] != c[1] {
+			return compareInts(c[0], c[1])
+		}
+	}
+
+	// A release has a higher precedence than its pre-releases
+	switch {
+	case a.Pre == b.Pre:
+		return 0
+	case a.Pre == "":
+		return 1
+	case b.Pre == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a.Pre, "."), strings.Split(b.Pre, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareIdentifiers(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(as), len(bs))
+}
+
+// compareIdentifiers compares pre-release identifiers, numeric identifiers
+// are lower than alphanumeric ones
+func compareIdentifiers(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareInts(an, bn)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Previous returns the highest tag lower than v, ignoring tags that are not
+// semantic versions. Pre-releases are only considered when v is a
+// pre-release itself, so the notes of a release cover the whole cycle.
+func Previous(tags []string, v Version) (string, bool) {
+	var previous Version
+	found := false
+	for _, tag := range tags {
+		t, err := Parse(tag)
+		if err != nil {
+			continue
+		}
+		if t.IsPrerelease() && !v.IsPrerelease() {
+			continue
+		}
+		if Compare(t, v) >= 0 {
+			continue
+		}
+		if !found || Compare(t, previous) > 0 {
+			previous, found = t, true
+		}
+	}
+	if !found {
+		return "", false
+	}
+	return previous.String(), true
+}
+
+// IsDotZero returns true for the first release of a minor, e.g. v1.2.0
+func (v Version) IsDotZero() bool {
+	return v.Patch == 0 && !v.IsPrerelease()
+}
+
+// XY returns the minor version, e.g. "1.2" for v1.2.3, as used in the
+// release-X.Y branch names
+func (v Version) XY() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
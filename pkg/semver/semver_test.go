@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	v, err := Parse("v1.2.3-alpha.4")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v != (Version{Major: 1, Minor: 2, Patch: 3, Pre: "alpha.4"}) || v.String() != "v1.2.3-alpha.4" {
+		t.Errorf("Unexpected version %+v", v)
+	}
+
+	for _, tag := range []string{"1.2.3", "v1.2", "v01.2.3", "v1.2.3-", "latest"} {
+		if _, err := Parse(tag); err == nil {
+			t.Errorf("Expected %q to be rejected", tag)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// In ascending order
+	ordered := []string{
+		"v0.7.9",
+		"v0.8.0-alpha.1",
+		"v0.8.0-alpha.2",
+		"v0.8.0-alpha.10",
+		"v0.8.0-beta.1",
+		"v0.8.0-rc.1",
+		"v0.8.0",
+		"v0.8.1",
+		"v1.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, _ := Parse(ordered[i])
+			b, _ := Parse(ordered[j])
+			expected := compareInts(i, j)
+			if c := Compare(a, b); c != expected {
+				t.Errorf("Expected Compare(%s, %s) to be %d but got %d", a, b, expected, c)
+			}
+		}
+	}
+}
+
+func TestPrevious(t *testing.T) {
+	tags := []string{"v0.7.0", "v0.7.2", "v0.8.0-alpha.1", "v0.8.0-alpha.2", "v0.8.0", "not-a-version", "v0.6.5"}
+	testCases := []struct {
+		version  string
+		expected string
+	}{
+		{version: "v0.8.0", expected: "v0.7.2"},
+		{version: "v0.8.0-alpha.2", expected: "v0.8.0-alpha.1"},
+		{version: "v0.8.0-beta.1", expected: "v0.8.0-alpha.2"},
+		{version: "v0.8.1", expected: "v0.8.0"},
+		{version: "v0.6.5", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		v, _ := Parse(tc.version)
+		previous, ok := Previous(tags, v)
+		if previous != tc.expected || ok != (tc.expected != "") {
+			t.Errorf("Expected previous of %s to be %q but got %q", tc.version, tc.expected, previous)
+		}
+	}
+}